@@ -0,0 +1,203 @@
+package nicehash
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// AlgoInfo is the metadata of an algorithm. The zero MinLimit, PriceStep
+// and DecreaseAmount are unknown, the built-in algorithms only have a name
+// and a unit until BuyInfo.Register fills them from the api.
+type AlgoInfo struct {
+	Algo AlgoType
+	Name string
+	// Unit is the market factor, the speed of one unit of the speeds and
+	// prices of the algorithm, eg. TH for SHA256.
+	Unit HashUnit
+	// UnitName is the name of Unit, eg. "TH/s" or "Sol/s".
+	UnitName string
+	// MinLimit is the smallest speed limit of an order, in Unit.
+	MinLimit SpeedLimit
+	// PriceStep is the precision of the prices.
+	PriceStep Price
+	// DecreaseAmount is the price decrease of OrderSetPriceDecrease.
+	DecreaseAmount Price
+}
+
+var algoRegistry = struct {
+	sync.RWMutex
+	algos map[AlgoType]AlgoInfo
+	names map[string]AlgoType
+}{algos: map[AlgoType]AlgoInfo{}, names: map[string]AlgoType{}}
+
+// init registers the names and the units of the built-in algorithms. The
+// limits and the price steps change on the server without notice, they are
+// not seeded here but read from buy.info, see BuyInfo.Register.
+func init() {
+	for _, info := range []struct {
+		algo AlgoType
+		name string
+		unit HashUnit
+	}{
+		{AlgoTypeScrypt, "Scrypt", GH},
+		{AlgoTypeSHA256, "SHA256", TH},
+		{AlgoTypeScryptNf, "ScryptNf", MH},
+		{AlgoTypeX11, "X11", GH},
+		{AlgoTypeX13, "X13", GH},
+		{AlgoTypeKeccak, "Keccak", GH},
+		{AlgoTypeX15, "X15", GH},
+		{AlgoTypeNist5, "Nist5", GH},
+		{AlgoTypeNeoScrypt, "NeoScrypt", GH},
+		{AlgoTypeLyra2RE, "Lyra2RE", GH},
+		{AlgoTypeWhirlpoolX, "WhirlpoolX", GH},
+		{AlgoTypeQubit, "Qubit", GH},
+		{AlgoTypeQuark, "Quark", GH},
+		{AlgoTypeAxiom, "Axiom", KH},
+		{AlgoTypeLyra2REv2, "Lyra2REv2", GH},
+		{AlgoTypeScryptJaneNf16, "ScryptJaneNf16", MH},
+		{AlgoTypeBlake256r8, "Blake256r8", TH},
+		{AlgoTypeBlake256r14, "Blake256r14", TH},
+		{AlgoTypeBlake256r8vnl, "Blake256r8vnl", TH},
+		{AlgoTypeHodl, "Hodl", KH},
+		{AlgoTypeDaggerHashimoto, "DaggerHashimoto", MH},
+		{AlgoTypeDecred, "Decred", TH},
+		{AlgoTypeCryptoNight, "CryptoNight", MH},
+		{AlgoTypeLbry, "Lbry", TH},
+		{AlgoTypeEquihash, "Equihash", H},
+		{AlgoTypePascal, "Pascal", TH},
+		{AlgoTypeX11Gost, "X11Gost", GH},
+		{AlgoTypeSia, "Sia", TH},
+		{AlgoTypeBlake2s, "Blake2s", TH},
+	} {
+		base := "H/s"
+		if info.algo == AlgoTypeEquihash {
+			base = "Sol/s"
+		}
+		RegisterAlgo(AlgoInfo{
+			Algo:     info.algo,
+			Name:     info.name,
+			Unit:     info.unit,
+			UnitName: info.unit.prefix() + base,
+		})
+	}
+}
+
+// RegisterAlgo adds an algorithm to the registry, or replaces the metadata
+// of a known one. It makes the algorithms added to NiceHash after this
+// package, from AlgoTypeMAX on, usable by name.
+func RegisterAlgo(info AlgoInfo) error {
+	if info.Algo < 0 {
+		return fmt.Errorf("nicehash: invalid algorithm number %d", int(info.Algo))
+	}
+	if info.Name == "" {
+		return fmt.Errorf("nicehash: algorithm %d without name", int(info.Algo))
+	}
+	if info.Unit == 0 {
+		info.Unit = H
+	}
+	if info.UnitName == "" {
+		info.UnitName = info.Unit.prefix() + "H/s"
+	}
+	key := strings.ToLower(info.Name)
+	algoRegistry.Lock()
+	defer algoRegistry.Unlock()
+	if other, ok := algoRegistry.names[key]; ok && other != info.Algo {
+		return fmt.Errorf("nicehash: algorithm name %s is used by %d", info.Name, int(other))
+	}
+	if old, ok := algoRegistry.algos[info.Algo]; ok {
+		delete(algoRegistry.names, strings.ToLower(old.Name))
+	}
+	algoRegistry.algos[info.Algo] = info
+	algoRegistry.names[key] = info.Algo
+	return nil
+}
+
+// LookupAlgo returns the metadata of the algorithm. MinLimit, PriceStep and
+// DecreaseAmount are zero until they are registered, see BuyInfo.Register.
+func LookupAlgo(t AlgoType) (AlgoInfo, bool) {
+	algoRegistry.RLock()
+	defer algoRegistry.RUnlock()
+	info, ok := algoRegistry.algos[t]
+	return info, ok
+}
+
+// Algos returns the registered algorithms ordered by number.
+func Algos() []AlgoInfo {
+	algoRegistry.RLock()
+	algos := make([]AlgoInfo, 0, len(algoRegistry.algos))
+	for _, info := range algoRegistry.algos {
+		algos = append(algos, info)
+	}
+	algoRegistry.RUnlock()
+	sort.Slice(algos, func(i, j int) bool { return algos[i].Algo < algos[j].Algo })
+	return algos
+}
+
+// ParseAlgo returns the algorithm of name, case-insensitive, eg.
+// "daggerhashimoto". The number of a registered algorithm is accepted too.
+func ParseAlgo(name string) (AlgoType, error) {
+	name = strings.TrimSpace(name)
+	algoRegistry.RLock()
+	algo, ok := algoRegistry.names[strings.ToLower(name)]
+	algoRegistry.RUnlock()
+	if ok {
+		return algo, nil
+	}
+	if number, err := strconv.Atoi(name); err == nil {
+		if _, ok := LookupAlgo(AlgoType(number)); ok {
+			return AlgoType(number), nil
+		}
+	}
+	return AlgoTypeNone, fmt.Errorf("nicehash: unknown algorithm %q", name)
+}
+
+// String returns the name of the algorithm, or its number when unknown.
+func (t AlgoType) String() string {
+	if info, ok := LookupAlgo(t); ok {
+		return info.Name
+	}
+	return strconv.Itoa(int(t))
+}
+
+func (t AlgoType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+func (t *AlgoType) UnmarshalText(text []byte) error {
+	algo, err := ParseAlgo(string(text))
+	if err != nil {
+		return err
+	}
+	*t = algo
+	return nil
+}
+
+// MarshalJSON keeps the number of the algorithm used by the api.
+func (t AlgoType) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Itoa(int(t))), nil
+}
+
+// UnmarshalJSON accepts the number or the name of the algorithm.
+func (t *AlgoType) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	if len(data) > 0 && data[0] == '"' {
+		var name string
+		if err := json.Unmarshal(data, &name); err != nil {
+			return err
+		}
+		return t.UnmarshalText([]byte(name))
+	}
+	var number int
+	if err := json.Unmarshal(data, &number); err != nil {
+		return err
+	}
+	*t = AlgoType(number)
+	return nil
+}
@@ -0,0 +1,128 @@
+package nicehash
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+	"github.com/stretchr/testify/assert"
+)
+
+// restoreAlgos restores the algorithm registry at the end of the test.
+func restoreAlgos(t *testing.T) {
+	algoRegistry.RLock()
+	algos := make(map[AlgoType]AlgoInfo, len(algoRegistry.algos))
+	for algo, info := range algoRegistry.algos {
+		algos[algo] = info
+	}
+	names := make(map[string]AlgoType, len(algoRegistry.names))
+	for name, algo := range algoRegistry.names {
+		names[name] = algo
+	}
+	algoRegistry.RUnlock()
+	t.Cleanup(func() {
+		algoRegistry.Lock()
+		algoRegistry.algos = algos
+		algoRegistry.names = names
+		algoRegistry.Unlock()
+	})
+}
+
+func TestParseAlgo(t *testing.T) {
+	algo, err := ParseAlgo("daggerhashimoto")
+	assert.Nil(t, err)
+	assert.Equal(t, AlgoTypeDaggerHashimoto, algo)
+
+	algo, err = ParseAlgo("SHA256")
+	assert.Nil(t, err)
+	assert.Equal(t, AlgoTypeSHA256, algo)
+
+	algo, err = ParseAlgo("24")
+	assert.Nil(t, err)
+	assert.Equal(t, AlgoTypeEquihash, algo)
+
+	algo, err = ParseAlgo(" 24\n")
+	assert.Nil(t, err)
+	assert.Equal(t, AlgoTypeEquihash, algo)
+
+	algo, err = ParseAlgo(" Sia ")
+	assert.Nil(t, err)
+	assert.Equal(t, AlgoTypeSia, algo)
+
+	_, err = ParseAlgo("nosuchalgo")
+	assert.NotNil(t, err)
+	algo, err = ParseAlgo("99")
+	assert.NotNil(t, err)
+	assert.Equal(t, AlgoTypeNone, algo)
+}
+
+func TestAlgoString(t *testing.T) {
+	assert.Equal(t, "DaggerHashimoto", AlgoTypeDaggerHashimoto.String())
+	assert.Equal(t, "Blake2s", AlgoTypeBlake2s.ToString())
+	assert.Equal(t, "NA", AlgoType(99).ToString())
+	assert.Equal(t, "99", AlgoType(99).String())
+}
+
+func TestBuiltinAlgoInfo(t *testing.T) {
+	info, ok := LookupAlgo(AlgoTypeSHA256)
+	assert.True(t, ok)
+	assert.Equal(t, AlgoInfo{Algo: AlgoTypeSHA256, Name: "SHA256", Unit: TH, UnitName: "TH/s"}, info)
+}
+
+func TestAlgoText(t *testing.T) {
+	var value struct {
+		Algo AlgoType `json:"algo"`
+	}
+	assert.Nil(t, json.Unmarshal([]byte(`{"algo":20}`), &value))
+	assert.Equal(t, AlgoTypeDaggerHashimoto, value.Algo)
+	assert.Nil(t, json.Unmarshal([]byte(`{"algo":"equihash"}`), &value))
+	assert.Equal(t, AlgoTypeEquihash, value.Algo)
+
+	data, err := json.Marshal(value)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"algo":24}`, string(data))
+
+	text, err := AlgoTypeScrypt.MarshalText()
+	assert.Nil(t, err)
+	assert.Equal(t, "Scrypt", string(text))
+	var algo AlgoType
+	assert.Nil(t, algo.UnmarshalText([]byte("x11")))
+	assert.Equal(t, AlgoTypeX11, algo)
+
+	values := url.Values{}
+	assert.Nil(t, AlgoTypeX11.EncodeValues("algo", &values))
+	assert.Equal(t, "3", values.Get("algo"))
+	assert.Nil(t, AlgoTypeMAX.EncodeValues("skunk", &values))
+	assert.Equal(t, "29", values.Get("skunk"))
+	assert.Nil(t, AlgoTypeNone.EncodeValues("none", &values))
+	_, ok := values["none"]
+	assert.False(t, ok)
+}
+
+func TestRegisterAlgo(t *testing.T) {
+	restoreAlgos(t)
+	lyra2z := AlgoType(32)
+	assert.Nil(t, RegisterAlgo(AlgoInfo{Algo: lyra2z, Name: "Lyra2Z", Unit: GH, MinLimit: 1000000, PriceStep: 10000, DecreaseAmount: 10000}))
+
+	algo, err := ParseAlgo("lyra2z")
+	assert.Nil(t, err)
+	assert.Equal(t, lyra2z, algo)
+	assert.Equal(t, "Lyra2Z", lyra2z.ToString())
+	assert.Equal(t, "GH/s", lyra2z.UnitName())
+	assert.Equal(t, "1.5 GH/s", NewHashrate(lyra2z, 1.5).String())
+
+	info, ok := LookupAlgo(lyra2z)
+	assert.True(t, ok)
+	assert.Equal(t, SpeedLimit(1000000), info.MinLimit)
+	assert.Equal(t, lyra2z, Algos()[len(Algos())-1].Algo)
+
+	assert.NotNil(t, RegisterAlgo(AlgoInfo{Algo: 33, Name: "sha256"}))
+	assert.NotNil(t, RegisterAlgo(AlgoInfo{Algo: AlgoTypeNone, Name: "None"}))
+	assert.NotNil(t, RegisterAlgo(AlgoInfo{Algo: 34}))
+
+	_, err = ParseAlgo("29")
+	assert.NotNil(t, err)
+	assert.Nil(t, RegisterAlgo(AlgoInfo{Algo: AlgoTypeMAX, Name: "Skunk", Unit: MH}))
+	algo, err = ParseAlgo("29")
+	assert.Nil(t, err)
+	assert.Equal(t, "Skunk", algo.ToString())
+}
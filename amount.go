@@ -0,0 +1,217 @@
+package nicehash
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Amount is an exact BTC amount in satoshis (1e-8 BTC). It is encoded as
+// a decimal string, eg. "0.0505", in json and in the query strings.
+type Amount int64
+
+// Price is the price of a unit of hashrate per day, eg. BTC/TH/day, with
+// the precision of an Amount.
+type Price = Amount
+
+const (
+	Satoshi Amount = 1
+	BTC     Amount = 1e8
+)
+
+const amountDecimals = 8
+
+// ParseAmount parses a decimal number with at most 8 significant
+// fractional digits, eg. "0.0505" or "-1.00000000".
+func ParseAmount(s string) (Amount, error) {
+	value, err := parseFixed(s, "amount")
+	return Amount(value), err
+}
+
+// parseFixed parses a decimal number with at most 8 significant fractional
+// digits as an integer of 1e-8 units, what names the number in the errors.
+func parseFixed(s string, what string) (int64, error) {
+	text := strings.TrimSpace(s)
+	negative := strings.HasPrefix(text, "-")
+	if negative || strings.HasPrefix(text, "+") {
+		// one sign only, "-+5" is refused by the digit check
+		text = text[1:]
+	}
+	whole, fraction, _ := strings.Cut(text, ".")
+	if whole == "" && fraction == "" {
+		return 0, fmt.Errorf("nicehash: invalid %s %q", what, s)
+	}
+	fraction = strings.TrimRight(fraction, "0")
+	if len(fraction) > amountDecimals {
+		return 0, fmt.Errorf("nicehash: %s %q has more than %d decimals", what, s, amountDecimals)
+	}
+	digits := whole + fraction + strings.Repeat("0", amountDecimals-len(fraction))
+	for _, c := range digits {
+		if c < '0' || c > '9' {
+			return 0, fmt.Errorf("nicehash: invalid %s %q", what, s)
+		}
+	}
+	value, err := strconv.ParseInt(digits, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("nicehash: %s %q out of range", what, s)
+	}
+	if negative {
+		value = -value
+	}
+	return value, nil
+}
+
+// AmountFromFloat returns the amount nearest to f BTC.
+func AmountFromFloat(f float64) Amount {
+	return Amount(math.Round(f * float64(BTC)))
+}
+
+// Float64 returns the amount in BTC, possibly rounded.
+func (a Amount) Float64() float64 {
+	return float64(a) / float64(BTC)
+}
+
+// Rat returns the exact amount in BTC.
+func (a Amount) Rat() *big.Rat {
+	return big.NewRat(int64(a), int64(BTC))
+}
+
+func (a Amount) Add(b Amount) Amount {
+	return a + b
+}
+
+func (a Amount) Sub(b Amount) Amount {
+	return a - b
+}
+
+// Mul returns a times n, eg. the cost of n units at price a.
+func (a Amount) Mul(n int64) Amount {
+	return a * Amount(n)
+}
+
+// MulFloat returns a times f rounded to the nearest satoshi.
+func (a Amount) MulFloat(f float64) Amount {
+	return Amount(math.Round(float64(a) * f))
+}
+
+// Div returns a divided by n rounded to the nearest satoshi, halves away
+// from zero. Dividing by zero is an error.
+func (a Amount) Div(n int64) (Amount, error) {
+	if n == 0 {
+		return 0, fmt.Errorf("nicehash: division of %s by zero", a)
+	}
+	q, r := int64(a)/n, int64(a)%n
+	if 2*abs(r) >= abs(n) {
+		if (r < 0) != (n < 0) {
+			q--
+		} else {
+			q++
+		}
+	}
+	return Amount(q), nil
+}
+
+func abs(n int64) int64 {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// Cmp returns -1, 0 or +1 as a is less than, equal to or greater than b.
+func (a Amount) Cmp(b Amount) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func (a Amount) IsZero() bool {
+	return a == 0
+}
+
+// String returns the amount in BTC without trailing zeros, eg. "0.0505".
+func (a Amount) String() string {
+	return formatFixed(int64(a))
+}
+
+// formatFixed formats an integer of 1e-8 units without trailing zeros.
+func formatFixed(n int64) string {
+	sign := ""
+	value := uint64(n)
+	if n < 0 {
+		sign = "-"
+		value = uint64(-n)
+	}
+	whole := value / uint64(BTC)
+	fraction := strings.TrimRight(fmt.Sprintf("%08d", value%uint64(BTC)), "0")
+	if fraction == "" {
+		return sign + strconv.FormatUint(whole, 10)
+	}
+	return sign + strconv.FormatUint(whole, 10) + "." + fraction
+}
+
+func (a Amount) MarshalJSON() ([]byte, error) {
+	return json.Marshal(a.String())
+}
+
+// UnmarshalJSON accepts json strings and numbers, the empty string is zero.
+// Unlike ParseAmount it rounds the numbers with more than 8 decimals.
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	return unmarshalFixed(data, "amount", (*int64)(a))
+}
+
+// unmarshalFixed decodes a json string or number into value, see
+// roundFixed. Null keeps value, the empty string is zero.
+func unmarshalFixed(data []byte, what string, value *int64) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	text := string(data)
+	if len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &text); err != nil {
+			return err
+		}
+		if text == "" {
+			*value = 0
+			return nil
+		}
+	}
+	parsed, err := roundFixed(text, what)
+	if err != nil {
+		return err
+	}
+	*value = parsed
+	return nil
+}
+
+// roundFixed parses a decimal number as an integer of 1e-8 units like
+// parseFixed, but it accepts exponents and more than 8 decimals too, eg.
+// "1.5e-05" or "0.123456789", rounded to the nearest unit, halves away
+// from zero. The api sends both, they must not fail a whole response.
+func roundFixed(s string, what string) (int64, error) {
+	text := strings.TrimSpace(s)
+	r, ok := new(big.Rat).SetString(text)
+	if !ok || strings.ContainsAny(text, "/_xXbBoOpP") {
+		return 0, fmt.Errorf("nicehash: invalid %s %q", what, s)
+	}
+	r.Mul(r, big.NewRat(int64(BTC), 1))
+	value, ok := new(big.Int).SetString(r.FloatString(0), 10)
+	if !ok || !value.IsInt64() {
+		return 0, fmt.Errorf("nicehash: %s %q out of range", what, s)
+	}
+	return value.Int64(), nil
+}
+
+func (a Amount) EncodeValues(key string, v *url.Values) error {
+	v.Add(key, a.String())
+	return nil
+}
@@ -0,0 +1,95 @@
+package nicehash
+
+import (
+	"encoding/json"
+	"testing"
+	"github.com/google/go-querystring/query"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAmount(t *testing.T) {
+	valid := map[string]Amount{
+		"0.0505":      5050000,
+		"0.00000001":  1,
+		"1":           BTC,
+		"1.":          BTC,
+		".5":          50000000,
+		"-2.10":       -210000000,
+		"0.050500000": 5050000,
+		"21000000":    21000000 * BTC,
+	}
+	for text, expected := range valid {
+		amount, err := ParseAmount(text)
+		assert.Nil(t, err, text)
+		assert.Equal(t, expected, amount, text)
+	}
+
+	for _, text := range []string{"", ".", "-", "abc", "1e-3", "0.000000001", "1.2.3", "99999999999999999999", "-+5", "+-5", "--5", "++5"} {
+		_, err := ParseAmount(text)
+		assert.NotNil(t, err, text)
+	}
+}
+
+func TestAmountString(t *testing.T) {
+	assert.Equal(t, "0.0505", Amount(5050000).String())
+	assert.Equal(t, "0", Amount(0).String())
+	assert.Equal(t, "1", BTC.String())
+	assert.Equal(t, "-0.00000001", (-Satoshi).String())
+	assert.Equal(t, "123.45678901", Amount(12345678901).String())
+}
+
+func TestAmountJSON(t *testing.T) {
+	var value struct {
+		A Amount `json:"a"`
+		B Amount `json:"b"`
+		C Amount `json:"c"`
+	}
+	assert.Nil(t, json.Unmarshal([]byte(`{"a":"0.0505","b":0.0117,"c":""}`), &value))
+	assert.Equal(t, Amount(5050000), value.A)
+	assert.Equal(t, Amount(1170000), value.B)
+	assert.Equal(t, Amount(0), value.C)
+
+	data, err := json.Marshal(value)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"a":"0.0505","b":"0.0117","c":"0"}`, string(data))
+
+	// the api numbers are rounded instead of failing the response
+	assert.Nil(t, json.Unmarshal([]byte(`{"a":"0.123456789","b":1.5E-5,"c":"-0.000000015"}`), &value))
+	assert.Equal(t, Amount(12345679), value.A)
+	assert.Equal(t, Amount(1500), value.B)
+	assert.Equal(t, Amount(-2), value.C)
+
+	for _, data := range []string{`{"a":"abc"}`, `{"a":"-+5"}`, `{"a":"0x10"}`, `{"a":"1e30"}`, `{"a":true}`} {
+		assert.NotNil(t, json.Unmarshal([]byte(data), &value), data)
+	}
+}
+
+func TestAmountQuery(t *testing.T) {
+	values, err := query.Values(&Params{Method: "orders.set.price", Price: 5050000})
+	assert.Nil(t, err)
+	assert.Equal(t, "0.0505", values.Get("price"))
+	_, ok := values["amount"]
+	assert.False(t, ok)
+}
+
+func TestAmountArithmetic(t *testing.T) {
+	price := Amount(5050000)
+	assert.Equal(t, Amount(5150000), price.Add(100000))
+	assert.Equal(t, Amount(4950000), price.Sub(100000))
+	assert.Equal(t, Amount(15150000), price.Mul(3))
+	for _, div := range []struct {
+		a, expected Amount
+		n           int64
+	}{{price, 1683333, 3}, {5, 2, 3}, {-5, -2, 3}, {5, -2, -3}} {
+		quotient, err := div.a.Div(div.n)
+		assert.Nil(t, err)
+		assert.Equal(t, div.expected, quotient)
+	}
+	_, err := price.Div(0)
+	assert.NotNil(t, err)
+	assert.Equal(t, Amount(6312500), price.MulFloat(1.25))
+	assert.Equal(t, Amount(5050000), AmountFromFloat(0.0505))
+	assert.Equal(t, 0.0505, price.Float64())
+	assert.Equal(t, -1, price.Cmp(price+1))
+	assert.True(t, Amount(0).IsZero())
+}
@@ -0,0 +1,54 @@
+package nicehash
+
+import (
+	"context"
+	"time"
+)
+
+// NicehashAPI is the api of NicehashClient, the configuration methods
+// excepted. See the nicehashtest package for a fake implementation.
+type NicehashAPI interface {
+	GetBalance() (Balance, error)
+	GetBalanceContext(ctx context.Context) (Balance, error)
+	GetBuyInfo() (BuyInfo, error)
+	GetBuyInfoContext(ctx context.Context) (BuyInfo, error)
+	GetMultiAlgoInfo() ([]MultiAlgo, error)
+	GetMultiAlgoInfoContext(ctx context.Context) ([]MultiAlgo, error)
+	GetSimpleMultiAlgoInfo() ([]MultiAlgo, error)
+	GetSimpleMultiAlgoInfoContext(ctx context.Context) ([]MultiAlgo, error)
+	GetOrders(algo AlgoType, location Location) ([]Orders, error)
+	GetOrdersContext(ctx context.Context, algo AlgoType, location Location) ([]Orders, error)
+	GetMyOrders(algo AlgoType, location Location) ([]MyOrders, error)
+	GetMyOrdersContext(ctx context.Context, algo AlgoType, location Location) ([]MyOrders, error)
+	OrderCreate(order NewOrder) (string, error)
+	OrderCreateContext(ctx context.Context, order NewOrder) (string, error)
+	GetFixedPrice(algo AlgoType, location Location, limit SpeedLimit) (FixedPriceQuote, error)
+	GetFixedPriceContext(ctx context.Context, algo AlgoType, location Location, limit SpeedLimit) (FixedPriceQuote, error)
+	OrderRefill(algo AlgoType, location Location, order uint, amount Amount) (string, error)
+	OrderRefillContext(ctx context.Context, algo AlgoType, location Location, order uint, amount Amount) (string, error)
+	OrderRemove(algo AlgoType, location Location, order uint) (string, error)
+	OrderRemoveContext(ctx context.Context, algo AlgoType, location Location, order uint) (string, error)
+	OrderSetPrice(algo AlgoType, location Location, order uint, price Price) (string, error)
+	OrderSetPriceContext(ctx context.Context, algo AlgoType, location Location, order uint, price Price) (string, error)
+	OrderSetPriceDecrease(algo AlgoType, location Location, order uint) (string, error)
+	OrderSetPriceDecreaseContext(ctx context.Context, algo AlgoType, location Location, order uint) (string, error)
+	OrderSetLimit(algo AlgoType, location Location, order uint, limit SpeedLimit) (string, error)
+	OrderSetLimitContext(ctx context.Context, algo AlgoType, location Location, order uint, limit SpeedLimit) (string, error)
+	GetStatsGlobalCurrent() ([]GlobalStats, error)
+	GetStatsGlobalCurrentContext(ctx context.Context) ([]GlobalStats, error)
+	GetStatsGlobalDay() ([]GlobalStats, error)
+	GetStatsGlobalDayContext(ctx context.Context) ([]GlobalStats, error)
+	GetStatsProvider(addr string) ([]ProviderStats, []ProviderPayments, error)
+	GetStatsProviderContext(ctx context.Context, addr string) ([]ProviderStats, []ProviderPayments, error)
+	GetStatsProviderEx(addr string) (StatsProviderEx, error)
+	GetStatsProviderExContext(ctx context.Context, addr string) (StatsProviderEx, error)
+	GetStatsProviderExSince(addr string, from time.Time) (StatsProviderEx, error)
+	GetStatsProviderExSinceContext(ctx context.Context, addr string, from time.Time) (StatsProviderEx, error)
+	ProviderExHistory(addr string, since time.Time, window time.Duration) *ProviderExHistoryIterator
+	GetStatsProviderWorkers(addr string, algo AlgoType) ([]ProviderWorker, error)
+	GetStatsProviderWorkersContext(ctx context.Context, addr string, algo AlgoType) ([]ProviderWorker, error)
+	GetVersion() (string, error)
+	GetVersionContext(ctx context.Context) (string, error)
+}
+
+var _ NicehashAPI = (*NicehashClient)(nil)
@@ -0,0 +1,216 @@
+package apiv2
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/bitbandi/go-nicehash-api"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Timestamp is a point in time sent as milliseconds since the epoch, either
+// as a json number or string, or as an RFC 3339 string.
+type Timestamp struct {
+	time.Time
+}
+
+func (t *Timestamp) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	text := string(data)
+	if len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &text); err != nil {
+			return err
+		}
+		if text == "" {
+			t.Time = time.Time{}
+			return nil
+		}
+	}
+	if millis, err := strconv.ParseInt(text, 10, 64); err == nil {
+		t.Time = time.UnixMilli(millis).UTC()
+		return nil
+	}
+	parsed, err := time.Parse(time.RFC3339Nano, text)
+	if err != nil {
+		return err
+	}
+	t.Time = parsed
+	return nil
+}
+
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.UnixMilli())
+}
+
+// Account is the balance of one currency.
+type Account struct {
+	Currency     string  `json:"currency"`
+	Active       bool    `json:"active"`
+	TotalBalance Decimal `json:"totalBalance"`
+	Available    Decimal `json:"available"`
+	Pending      Decimal `json:"pending"`
+	BtcRate      Decimal `json:"btcRate"`
+}
+
+// Accounts returns the balances of every currency and their total in BTC.
+func (client *Client) Accounts(ctx context.Context) (Account, []Account, error) {
+	accounts := &struct {
+		Total      Account   `json:"total"`
+		Currencies []Account `json:"currencies"`
+	}{}
+	_, err := client.receive(ctx, client.sling.New().Get("main/api/v2/accounting/accounts2"), nicehash.RateLimitPrivate, accounts)
+	if err != nil {
+		return Account{}, nil, err
+	}
+	return accounts.Total, accounts.Currencies, nil
+}
+
+// Account returns the balance of currency, eg. "BTC".
+func (client *Client) Account(ctx context.Context, currency string) (Account, error) {
+	account := Account{}
+	_, err := client.receive(ctx, client.sling.New().Get("main/api/v2/accounting/account2/"+url.PathEscape(currency)), nicehash.RateLimitPrivate, &account)
+	return account, err
+}
+
+type Transaction struct {
+	Id          string    `json:"id"`
+	Created     Timestamp `json:"created"`
+	Currency    string    `json:"currency"`
+	Type        string    `json:"type"`
+	Purpose     string    `json:"purpose"`
+	Amount      Decimal   `json:"amount"`
+	FeeAmount   Decimal   `json:"feeAmount"`
+	AccountType string    `json:"accountType"`
+	Time        Timestamp `json:"time"`
+}
+
+// TransactionsQuery selects a page of the transactions.
+type TransactionsQuery struct {
+	Type    string `url:"type,omitempty"`    // eg. "DEPOSIT", "WITHDRAWAL", "HASHPOWER", "MINING"
+	Purpose string `url:"purpose,omitempty"` // eg. "PAYOUT" or "FEE"
+	// Until selects the transactions created before it, zero means now.
+	Until time.Time `url:"-"`
+	Page  int       `url:"page"`
+	Size  int       `url:"size"` // zero means 100
+}
+
+// Transactions returns a page of the transactions of currency.
+func (client *Client) Transactions(ctx context.Context, currency string, query TransactionsQuery) ([]Transaction, Pagination, error) {
+	transactions := &struct {
+		List       []Transaction `json:"list"`
+		Pagination Pagination    `json:"pagination"`
+	}{}
+	if query.Size == 0 {
+		query.Size = 100
+	}
+	timestamp := &struct {
+		Timestamp int64 `url:"timestamp,omitempty"`
+	}{}
+	if !query.Until.IsZero() {
+		timestamp.Timestamp = query.Until.UnixMilli()
+	}
+	_, err := client.receive(ctx, client.sling.New().Get("main/api/v2/accounting/transactions/"+url.PathEscape(currency)).QueryStruct(query).QueryStruct(timestamp), nicehash.RateLimitPrivate, transactions)
+	if err != nil {
+		return nil, Pagination{}, err
+	}
+	return transactions.List, transactions.Pagination, nil
+}
+
+// WalletType is the kind of wallet behind an address, eg. "BITGO".
+type WalletType string
+
+func (t *WalletType) UnmarshalJSON(data []byte) error {
+	return unmarshalCode(data, "code", (*string)(t))
+}
+
+type DepositAddress struct {
+	Type     string `json:"type"`
+	Address  string `json:"address"`
+	Currency string `json:"currency"`
+}
+
+// UnmarshalJSON accepts the wallet type as a string or an object with a code field.
+func (d *DepositAddress) UnmarshalJSON(data []byte) error {
+	type Alias DepositAddress
+	aux := &struct {
+		Type json.RawMessage `json:"type"`
+		*Alias
+	}{
+		Alias: (*Alias)(d),
+	}
+	if err := json.Unmarshal(data, aux); err != nil {
+		return err
+	}
+	if aux.Type == nil {
+		return nil
+	}
+	return unmarshalCode(aux.Type, "code", &d.Type)
+}
+
+// DepositAddresses returns the addresses receiving deposits of currency.
+func (client *Client) DepositAddresses(ctx context.Context, currency string) ([]DepositAddress, error) {
+	addresses := &struct {
+		List []DepositAddress `json:"list"`
+	}{}
+	params := &struct {
+		Currency string `url:"currency"`
+	}{currency}
+	_, err := client.receive(ctx, client.sling.New().Get("main/api/v2/accounting/depositAddresses").QueryStruct(params), nicehash.RateLimitPrivate, addresses)
+	if err != nil {
+		return nil, err
+	}
+	return addresses.List, nil
+}
+
+type WithdrawalStatus string
+
+func (s *WithdrawalStatus) UnmarshalJSON(data []byte) error {
+	return unmarshalCode(data, "code", (*string)(s))
+}
+
+type Withdrawal struct {
+	Id        string           `json:"id"`
+	Created   Timestamp        `json:"created"`
+	Currency  string           `json:"currency"`
+	Amount    Decimal          `json:"amount"`
+	FeeAmount Decimal          `json:"feeAmount"`
+	Status    WithdrawalStatus `json:"status"`
+	Address   string           `json:"address"`
+	TxId      string           `json:"txId"`
+}
+
+// WithdrawalsQuery selects a page of the withdrawals.
+type WithdrawalsQuery struct {
+	// Until selects the withdrawals created before it, zero means now.
+	Until time.Time `url:"-"`
+	Page  int       `url:"page"`
+	Size  int       `url:"size"` // zero means 100
+}
+
+// Withdrawals returns a page of the withdrawal history of currency.
+func (client *Client) Withdrawals(ctx context.Context, currency string, query WithdrawalsQuery) ([]Withdrawal, Pagination, error) {
+	withdrawals := &struct {
+		List       []Withdrawal `json:"list"`
+		Pagination Pagination   `json:"pagination"`
+	}{}
+	if query.Size == 0 {
+		query.Size = 100
+	}
+	until := query.Until
+	if until.IsZero() {
+		until = time.Now()
+	}
+	page := &struct {
+		Timestamp int64  `url:"timestamp"`
+		Op        string `url:"op"`
+	}{until.UnixMilli(), "LE"}
+	_, err := client.receive(ctx, client.sling.New().Get("main/api/v2/accounting/withdrawals/"+url.PathEscape(currency)).QueryStruct(query).QueryStruct(page), nicehash.RateLimitPrivate, withdrawals)
+	if err != nil {
+		return nil, Pagination{}, err
+	}
+	return withdrawals.List, withdrawals.Pagination, nil
+}
@@ -0,0 +1,133 @@
+package apiv2
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccounts(t *testing.T) {
+	client, mux, server := testServer(t)
+	defer server.Close()
+
+	sampleItem := `{
+	   "total":{"currency":"BTC","totalBalance":"0.01751439","available":"0.01500000","pending":"0.00251439"},
+	   "currencies":[
+	      {"active":true,"currency":"BTC","totalBalance":"0.01751439","available":"0.01500000","pending":"0.00251439","btcRate":1.0},
+	      {"active":true,"currency":"ETH","totalBalance":"1.000000000000000001","available":"1.000000000000000001","pending":"0","btcRate":"0.0345"}
+	   ]
+	}`
+
+	mux.HandleFunc("/main/api/v2/accounting/accounts2", func(w http.ResponseWriter, r *http.Request) {
+		assertSigned(t, r)
+		assert.Equal(t, "GET", r.Method)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, sampleItem)
+	})
+
+	total, currencies, err := client.Accounts(context.Background())
+
+	assert.Nil(t, err)
+	assert.Equal(t, Account{Currency: "BTC", TotalBalance: "0.01751439", Available: "0.01500000", Pending: "0.00251439"}, total)
+	assert.Equal(t, []Account{
+		{Currency: "BTC", Active: true, TotalBalance: "0.01751439", Available: "0.01500000", Pending: "0.00251439", BtcRate: "1.0"},
+		{Currency: "ETH", Active: true, TotalBalance: "1.000000000000000001", Available: "1.000000000000000001", Pending: "0", BtcRate: "0.0345"},
+	}, currencies)
+}
+
+func TestAccount(t *testing.T) {
+	client, mux, server := testServer(t)
+	defer server.Close()
+
+	mux.HandleFunc("/main/api/v2/accounting/account2/BTC", func(w http.ResponseWriter, r *http.Request) {
+		assertSigned(t, r)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"active":true,"currency":"BTC","totalBalance":"0.005","available":"0.005","pending":"0"}`)
+	})
+
+	account, err := client.Account(context.Background(), "BTC")
+
+	assert.Nil(t, err)
+	assert.Equal(t, Account{Currency: "BTC", Active: true, TotalBalance: "0.005", Available: "0.005", Pending: "0"}, account)
+}
+
+func TestTransactions(t *testing.T) {
+	client, mux, server := testServer(t)
+	defer server.Close()
+
+	sampleItem := `{
+	   "list":[
+	      {"id":"T1","created":1549015200000,"currency":"BTC","type":"MINING","purpose":"PAYOUT","amount":"0.00012345","feeAmount":"0.00000123","accountType":"USER","time":"1549015200000"}
+	   ],
+	   "pagination":{"size":100,"page":0,"totalPageCount":1}
+	}`
+
+	mux.HandleFunc("/main/api/v2/accounting/transactions/BTC", func(w http.ResponseWriter, r *http.Request) {
+		assertSigned(t, r)
+		assert.Equal(t, "MINING", r.URL.Query().Get("type"))
+		assert.Equal(t, "1549015300000", r.URL.Query().Get("timestamp"))
+		assert.Equal(t, "0", r.URL.Query().Get("page"))
+		assert.Equal(t, "100", r.URL.Query().Get("size"))
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, sampleItem)
+	})
+
+	created := Timestamp{time.Date(2019, 2, 1, 10, 0, 0, 0, time.UTC)}
+	transactions, pagination, err := client.Transactions(context.Background(), "BTC", TransactionsQuery{
+		Type:  "MINING",
+		Until: time.UnixMilli(1549015300000),
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, []Transaction{
+		{Id: "T1", Created: created, Currency: "BTC", Type: "MINING", Purpose: "PAYOUT", Amount: "0.00012345", FeeAmount: "0.00000123", AccountType: "USER", Time: created},
+	}, transactions)
+	assert.Equal(t, Pagination{Size: 100, Page: 0, TotalPageCount: 1}, pagination)
+}
+
+func TestDepositAddresses(t *testing.T) {
+	client, mux, server := testServer(t)
+	defer server.Close()
+
+	mux.HandleFunc("/main/api/v2/accounting/depositAddresses", func(w http.ResponseWriter, r *http.Request) {
+		assertSigned(t, r)
+		assert.Equal(t, "BTC", r.URL.Query().Get("currency"))
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"list":[{"type":{"code":"BITGO","description":"BitGo"},"address":"3FakeAddress","currency":"BTC"}]}`)
+	})
+
+	addresses, err := client.DepositAddresses(context.Background(), "BTC")
+
+	assert.Nil(t, err)
+	assert.Equal(t, []DepositAddress{{Type: "BITGO", Address: "3FakeAddress", Currency: "BTC"}}, addresses)
+}
+
+func TestWithdrawals(t *testing.T) {
+	client, mux, server := testServer(t)
+	defer server.Close()
+
+	mux.HandleFunc("/main/api/v2/accounting/withdrawals/BTC", func(w http.ResponseWriter, r *http.Request) {
+		assertSigned(t, r)
+		assert.Equal(t, "LE", r.URL.Query().Get("op"))
+		assert.NotEmpty(t, r.URL.Query().Get("timestamp"))
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"list":[{"id":"W1","created":"2019-02-01T10:00:00Z","currency":"BTC","amount":"0.1","feeAmount":"0.0001","status":{"code":"COMPLETED"},"address":"3FakeAddress","txId":"abc"}],"pagination":{"size":100,"page":0,"totalPageCount":1}}`)
+	})
+
+	withdrawals, _, err := client.Withdrawals(context.Background(), "BTC", WithdrawalsQuery{})
+
+	assert.Nil(t, err)
+	assert.Equal(t, []Withdrawal{{
+		Id:        "W1",
+		Created:   Timestamp{time.Date(2019, 2, 1, 10, 0, 0, 0, time.UTC)},
+		Currency:  "BTC",
+		Amount:    "0.1",
+		FeeAmount: "0.0001",
+		Status:    "COMPLETED",
+		Address:   "3FakeAddress",
+		TxId:      "abc",
+	}}, withdrawals)
+}
@@ -0,0 +1,278 @@
+// Package apiv2 is a client of the NiceHash REST api (v2).
+//
+// The private endpoints are authenticated by an HMAC-SHA256 signature of
+// every request, made with the api key and secret of an organization.
+package apiv2
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/bitbandi/go-nicehash-api"
+	"github.com/dghubble/sling"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const DefaultBaseURL = "https://api2.nicehash.com/"
+
+type Client struct {
+	sling       *sling.Sling
+	baseURL     string
+	httpClient  *signingHttpClient
+	withdrawals *withdrawalGuard
+}
+
+// signingHttpClient signs the private requests and keeps the rate limits.
+type signingHttpClient struct {
+	client    *http.Client
+	useragent string
+	limiter   *nicehash.RateLimiter
+
+	orgId     string
+	apiKey    string
+	apiSecret string
+
+	mu         sync.Mutex
+	synced     bool
+	timeOffset time.Duration // server time minus local time
+	sync       func(ctx context.Context) (time.Time, error)
+}
+
+// Option configures a Client created by New.
+type Option func(*Client) error
+
+// New returns a client configured by opts.
+func New(opts ...Option) (*Client, error) {
+	httpClient := &signingHttpClient{
+		limiter: nicehash.NewRateLimiter(nicehash.DefaultPublicRateLimit, nicehash.DefaultPrivateRateLimit),
+	}
+	client := &Client{
+		httpClient: httpClient,
+		baseURL:    DefaultBaseURL,
+	}
+	for _, opt := range opts {
+		if err := opt(client); err != nil {
+			return nil, err
+		}
+	}
+	client.sling = sling.New().Doer(httpClient).Base(strings.TrimRight(client.baseURL, "/") + "/")
+	httpClient.sync = client.ServerTime
+	return client, nil
+}
+
+// WithHTTPClient sets the http client used to send the requests.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(client *Client) error {
+		client.httpClient.client = httpClient
+		return nil
+	}
+}
+
+// WithBaseURL sets the url of the api server, empty means DefaultBaseURL.
+func WithBaseURL(baseURL string) Option {
+	return func(client *Client) error {
+		if baseURL != "" {
+			client.baseURL = baseURL
+		}
+		return nil
+	}
+}
+
+// WithCredentials sets the organization id, api key and api secret needed
+// by the private endpoints.
+func WithCredentials(orgId string, apiKey string, apiSecret string) Option {
+	return func(client *Client) error {
+		client.httpClient.orgId = orgId
+		client.httpClient.apiKey = apiKey
+		client.httpClient.apiSecret = apiSecret
+		return nil
+	}
+}
+
+// WithUserAgent sets the User-Agent header of the requests.
+func WithUserAgent(userAgent string) Option {
+	return func(client *Client) error {
+		client.httpClient.useragent = userAgent
+		return nil
+	}
+}
+
+// WithRateLimiter sets the rate limiter, nil disables rate limiting.
+// Public endpoints use the public budget, signed ones the private budget.
+func WithRateLimiter(limiter *nicehash.RateLimiter) Option {
+	return func(client *Client) error {
+		client.httpClient.limiter = limiter
+		return nil
+	}
+}
+
+// APIError is returned when the server answers with a non 2xx http status.
+type APIError struct {
+	StatusCode int           `json:"-"`
+	ErrorId    string        `json:"error_id"`
+	Errors     []ErrorDetail `json:"errors"`
+}
+
+type ErrorDetail struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *APIError) Error() string {
+	messages := make([]string, 0, len(e.Errors))
+	for _, detail := range e.Errors {
+		messages = append(messages, fmt.Sprintf("%s (%d)", detail.Message, detail.Code))
+	}
+	if len(messages) == 0 {
+		messages = append(messages, http.StatusText(e.StatusCode))
+	}
+	return fmt.Sprintf("nicehash: http %d: %s", e.StatusCode, strings.Join(messages, ", "))
+}
+
+type contextKey struct{}
+
+// receive sends the request built by s, signed when class is private, and
+// decodes a successful response into success.
+func (client *Client) receive(ctx context.Context, s *sling.Sling, class nicehash.RateLimitClass, success interface{}) (*http.Response, error) {
+	req, err := s.Request()
+	if err != nil {
+		return nil, err
+	}
+	failure := &APIError{}
+	resp, err := s.Do(req.WithContext(context.WithValue(ctx, contextKey{}, class)), success, failure)
+	if err != nil {
+		return resp, err
+	}
+	if code := resp.StatusCode; code < 200 || 299 < code {
+		failure.StatusCode = code
+		return resp, failure
+	}
+	return resp, nil
+}
+
+// ServerTime returns the time of the server and adjusts the clock used to
+// sign the requests to it.
+func (client *Client) ServerTime(ctx context.Context) (time.Time, error) {
+	serverTime := &struct {
+		ServerTime int64 `json:"serverTime"`
+	}{}
+	start := time.Now()
+	_, err := client.receive(ctx, client.sling.New().Get("api/v2/time"), nicehash.RateLimitPublic, serverTime)
+	if err != nil {
+		return time.Time{}, err
+	}
+	now := time.UnixMilli(serverTime.ServerTime)
+	local := start.Add(time.Since(start) / 2)
+	client.httpClient.mu.Lock()
+	client.httpClient.timeOffset = now.Sub(local)
+	client.httpClient.synced = true
+	client.httpClient.mu.Unlock()
+	return now, nil
+}
+
+func (d *signingHttpClient) Do(req *http.Request) (*http.Response, error) {
+	if d.useragent != "" {
+		req.Header.Set("User-Agent", d.useragent)
+	}
+	class, _ := req.Context().Value(contextKey{}).(nicehash.RateLimitClass)
+	if d.limiter != nil {
+		if err := d.limiter.Wait(req.Context(), class); err != nil {
+			return nil, err
+		}
+	}
+	// signed after the wait, the server rejects stale X-Time headers
+	if class == nicehash.RateLimitPrivate {
+		if err := d.sign(req); err != nil {
+			return nil, err
+		}
+	}
+	client := d.client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err == nil && resp.StatusCode == http.StatusTooManyRequests && d.limiter != nil {
+		delay, _ := strconv.Atoi(resp.Header.Get("Retry-After"))
+		d.limiter.Backoff(class, time.Duration(delay)*time.Second)
+	}
+	return resp, err
+}
+
+// sign adds the authentication headers to req. The clock is synchronized
+// with the server before the first signature.
+func (d *signingHttpClient) sign(req *http.Request) error {
+	if d.apiKey == "" || d.apiSecret == "" {
+		return fmt.Errorf("nicehash: %s %s needs api credentials", req.Method, req.URL.Path)
+	}
+	d.mu.Lock()
+	synced := d.synced
+	d.mu.Unlock()
+	if !synced {
+		if _, err := d.sync(req.Context()); err != nil {
+			return fmt.Errorf("nicehash: time sync: %w", err)
+		}
+	}
+	var body []byte
+	if req.Body != nil {
+		var err error
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return err
+		}
+		req.Body.Close()
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	d.mu.Lock()
+	xtime := strconv.FormatInt(time.Now().Add(d.timeOffset).UnixMilli(), 10)
+	d.mu.Unlock()
+	nonce, err := newUUID()
+	if err != nil {
+		return err
+	}
+	requestId, err := newUUID()
+	if err != nil {
+		return err
+	}
+	req.Header.Set("X-Time", xtime)
+	req.Header.Set("X-Nonce", nonce)
+	req.Header.Set("X-Organization-Id", d.orgId)
+	req.Header.Set("X-Request-Id", requestId)
+	req.Header.Set("X-Auth", d.apiKey+":"+Signature(d.apiSecret, d.apiKey, xtime, nonce, d.orgId, req.Method, req.URL.EscapedPath(), req.URL.RawQuery, body))
+	return nil
+}
+
+// Signature returns the hex encoded HMAC-SHA256 signature of a request as
+// expected in the X-Auth header (after the api key and a colon).
+func Signature(apiSecret, apiKey, xtime, nonce, orgId, method, path, query string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(apiSecret))
+	for i, part := range []string{apiKey, xtime, nonce, "", orgId, "", method, path, query} {
+		if i > 0 {
+			mac.Write([]byte{0})
+		}
+		mac.Write([]byte(part))
+	}
+	if len(body) > 0 {
+		mac.Write([]byte{0})
+		mac.Write(body)
+	}
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// newUUID returns a random (version 4) uuid.
+func newUUID() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
+}
@@ -0,0 +1,159 @@
+package apiv2
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/bitbandi/go-nicehash-api"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testOrgId     = "FAKEORG"
+	testApiKey    = "FAKEKEY"
+	testApiSecret = "FAKESECRET"
+)
+
+// testServerTime is one hour ahead of the local clock.
+func testServerTime() time.Time {
+	return time.Now().Add(time.Hour)
+}
+
+// Use the client to make requests on the server.
+// Register handlers on mux to handle requests, signed requests are
+// verified before they reach the handlers.
+// Caller must close test server.
+func testServer(t *testing.T) (*Client, *http.ServeMux, *httptest.Server) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v2/time", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"serverTime":%d}`, testServerTime().UnixMilli())
+	})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Auth") != "" && !checkSignature(t, r) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"error_id":"ERR","errors":[{"code":2003,"message":"Invalid signature"}]}`)
+			return
+		}
+		mux.ServeHTTP(w, r)
+	}))
+	client, err := New(
+		WithBaseURL(server.URL),
+		WithCredentials(testOrgId, testApiKey, testApiSecret),
+		WithRateLimiter(nil),
+	)
+	assert.Nil(t, err)
+	return client, mux, server
+}
+
+func checkSignature(t *testing.T, r *http.Request) bool {
+	body, err := io.ReadAll(r.Body)
+	assert.Nil(t, err)
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	xtime, err := strconv.ParseInt(r.Header.Get("X-Time"), 10, 64)
+	assert.Nil(t, err)
+	assert.InDelta(t, testServerTime().UnixMilli(), xtime, 5000)
+	assert.Equal(t, testOrgId, r.Header.Get("X-Organization-Id"))
+	assert.Len(t, r.Header.Get("X-Nonce"), 36)
+	assert.Len(t, r.Header.Get("X-Request-Id"), 36)
+
+	expected := testApiKey + ":" + Signature(testApiSecret, testApiKey, r.Header.Get("X-Time"), r.Header.Get("X-Nonce"),
+		r.Header.Get("X-Organization-Id"), r.Method, r.URL.EscapedPath(), r.URL.RawQuery, body)
+	return r.Header.Get("X-Auth") == expected
+}
+
+// assertSigned fails the test when r was sent without a signature.
+func assertSigned(t *testing.T, r *http.Request) {
+	assert.True(t, strings.HasPrefix(r.Header.Get("X-Auth"), testApiKey+":"))
+}
+
+func TestServerTime(t *testing.T) {
+	client, _, server := testServer(t)
+	defer server.Close()
+
+	serverTime, err := client.ServerTime(context.Background())
+
+	assert.Nil(t, err)
+	assert.WithinDuration(t, testServerTime(), serverTime, time.Second)
+}
+
+func TestSignedRequest(t *testing.T) {
+	client, mux, server := testServer(t)
+	defer server.Close()
+
+	mux.HandleFunc("/main/api/v2/test", func(w http.ResponseWriter, r *http.Request) {
+		assertSigned(t, r)
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "1", r.URL.Query().Get("a"))
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"ok":true}`)
+	})
+
+	result := &struct {
+		Ok bool `json:"ok"`
+	}{}
+	body := map[string]string{"hello": "world"}
+	_, err := client.receive(context.Background(), client.sling.New().Post("main/api/v2/test?a=1").BodyJSON(body), nicehash.RateLimitPrivate, result)
+
+	assert.Nil(t, err)
+	assert.True(t, result.Ok)
+}
+
+func TestSignedAfterRateLimitWait(t *testing.T) {
+	client, mux, server := testServer(t)
+	defer server.Close()
+
+	mux.HandleFunc("/main/api/v2/test", func(w http.ResponseWriter, r *http.Request) {
+		assertSigned(t, r)
+		xtime, err := strconv.ParseInt(r.Header.Get("X-Time"), 10, 64)
+		assert.Nil(t, err)
+		assert.InDelta(t, testServerTime().UnixMilli(), xtime, 500)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{}`)
+	})
+
+	_, err := client.ServerTime(context.Background())
+	assert.Nil(t, err)
+	limiter := nicehash.NewRateLimiter(nicehash.RateLimit{}, nicehash.RateLimit{})
+	limiter.Backoff(nicehash.RateLimitPrivate, 1500*time.Millisecond)
+	WithRateLimiter(limiter)(client)
+	_, err = client.receive(context.Background(), client.sling.New().Get("main/api/v2/test"), nicehash.RateLimitPrivate, nil)
+
+	assert.Nil(t, err)
+}
+
+func TestSignedRequestWrongSecret(t *testing.T) {
+	client, mux, server := testServer(t)
+	defer server.Close()
+
+	mux.HandleFunc("/main/api/v2/test", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("unexpected request")
+	})
+
+	WithCredentials(testOrgId, testApiKey, "WRONG")(client)
+	_, err := client.receive(context.Background(), client.sling.New().Get("main/api/v2/test"), nicehash.RateLimitPrivate, nil)
+
+	assert.Equal(t, &APIError{
+		StatusCode: http.StatusUnauthorized,
+		ErrorId:    "ERR",
+		Errors:     []ErrorDetail{{Code: 2003, Message: "Invalid signature"}},
+	}, err)
+}
+
+func TestMissingCredentials(t *testing.T) {
+	client, err := New(WithBaseURL("http://127.0.0.1:1/"))
+	assert.Nil(t, err)
+
+	_, err = client.receive(context.Background(), client.sling.New().Get("main/api/v2/test"), nicehash.RateLimitPrivate, nil)
+
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,127 @@
+package apiv2
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+)
+
+// Decimal is an exact decimal number, kept as the text sent by the api,
+// eg. "0.00012000". The zero value is zero.
+type Decimal string
+
+// plainDecimal is the only syntax accepted by ParseDecimal, the exponents,
+// signs, underscores and hex or binary numbers of big.Rat are refused so
+// the text sent to the server is the number checked.
+var plainDecimal = regexp.MustCompile(`^-?[0-9]+(\.[0-9]+)?$`)
+
+// ParseDecimal validates s and returns it as a Decimal. Only plain decimal
+// numbers are accepted, eg. "0.0505" or "-1".
+func ParseDecimal(s string) (Decimal, error) {
+	if !plainDecimal.MatchString(s) {
+		return "", fmt.Errorf("nicehash: invalid decimal %q", s)
+	}
+	return Decimal(s), nil
+}
+
+// maxDecimalDigits bounds the fractional digits of the json numbers
+// rewritten by UnmarshalJSON.
+const maxDecimalDigits = 32
+
+// decimalFromNumber rewrites a json number with an exponent, eg. 1.0E-4,
+// as a plain decimal.
+func decimalFromNumber(text string) (Decimal, error) {
+	r, ok := new(big.Rat).SetString(text)
+	if !ok || strings.ContainsAny(text, "/_xXbBoOpP") {
+		return "", fmt.Errorf("nicehash: invalid decimal %q", text)
+	}
+	if d := exactDecimal(r); d.Rat().Cmp(r) == 0 {
+		return d, nil
+	}
+	return "", fmt.Errorf("nicehash: invalid decimal %q", text)
+}
+
+// exactDecimal returns r with the fewest fractional digits needed, at most
+// maxDecimalDigits.
+func exactDecimal(r *big.Rat) Decimal {
+	for prec := 0; prec < maxDecimalDigits; prec++ {
+		if d := DecimalFromRat(r, prec); d.Rat().Cmp(r) == 0 {
+			return d
+		}
+	}
+	return DecimalFromRat(r, maxDecimalDigits)
+}
+
+// DecimalFromRat returns r with the given number of fractional digits.
+func DecimalFromRat(r *big.Rat, prec int) Decimal {
+	return Decimal(r.FloatString(prec))
+}
+
+// Rat returns the exact value of d. Invalid decimals are zero.
+func (d Decimal) Rat() *big.Rat {
+	r, ok := new(big.Rat).SetString(string(d))
+	if !ok {
+		return new(big.Rat)
+	}
+	return r
+}
+
+// Float64 returns the nearest float64 of d.
+func (d Decimal) Float64() float64 {
+	f, _ := d.Rat().Float64()
+	return f
+}
+
+// Cmp compares d and o, see big.Rat.Cmp.
+func (d Decimal) Cmp(o Decimal) int {
+	return d.Rat().Cmp(o.Rat())
+}
+
+func (d Decimal) IsZero() bool {
+	return d.Rat().Sign() == 0
+}
+
+func (d Decimal) String() string {
+	if d == "" {
+		return "0"
+	}
+	return string(d)
+}
+
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON accepts json strings of plain decimals and json numbers,
+// the numbers with an exponent are rewritten as plain decimals.
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	text := string(data)
+	if len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &text); err != nil {
+			return err
+		}
+		if text == "" {
+			*d = ""
+			return nil
+		}
+	} else if !plainDecimal.MatchString(text) {
+		parsed, err := decimalFromNumber(text)
+		if err != nil {
+			return err
+		}
+		*d = parsed
+		return nil
+	}
+	parsed, err := ParseDecimal(text)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
@@ -0,0 +1,54 @@
+package apiv2
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecimalJSON(t *testing.T) {
+	var values struct {
+		Quoted Decimal `json:"quoted"`
+		Number Decimal `json:"number"`
+		Empty  Decimal `json:"empty"`
+		Null   Decimal `json:"null"`
+	}
+	err := json.Unmarshal([]byte(`{"quoted":"0.00012000","number":0.0505,"empty":"","null":null}`), &values)
+
+	assert.Nil(t, err)
+	assert.Equal(t, Decimal("0.00012000"), values.Quoted)
+	assert.Equal(t, Decimal("0.0505"), values.Number)
+	assert.True(t, values.Empty.IsZero())
+	assert.True(t, values.Null.IsZero())
+
+	data, err := json.Marshal(values)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"quoted":"0.00012000","number":"0.0505","empty":"0","null":"0"}`, string(data))
+
+	assert.NotNil(t, json.Unmarshal([]byte(`{"quoted":"abc"}`), &values))
+	assert.NotNil(t, json.Unmarshal([]byte(`{"quoted":"1/3"}`), &values))
+	assert.NotNil(t, json.Unmarshal([]byte(`{"quoted":"1e-1"}`), &values))
+
+	assert.Nil(t, json.Unmarshal([]byte(`{"number":1.0E-4}`), &values))
+	assert.Equal(t, Decimal("0.0001"), values.Number)
+}
+
+func TestParseDecimal(t *testing.T) {
+	for _, text := range []string{"0", "-1", "0.0505", "0.00012000", "21000000"} {
+		d, err := ParseDecimal(text)
+		assert.Nil(t, err, text)
+		assert.Equal(t, Decimal(text), d)
+	}
+	for _, text := range []string{"", "0x1p-1", "1e-1", "0b1", "0o7", "1_000", "+1", ".5", "1.", "1/3", " 1", "--1"} {
+		_, err := ParseDecimal(text)
+		assert.NotNil(t, err, text)
+	}
+}
+
+func TestDecimalCmp(t *testing.T) {
+	assert.Equal(t, 0, Decimal("0.10").Cmp("0.1"))
+	assert.Equal(t, -1, Decimal("0.0505").Cmp("0.051"))
+	assert.Equal(t, 1, Decimal("1").Cmp(""))
+	assert.Equal(t, Decimal("0.30000000"), DecimalFromRat(new(big.Rat).Add(Decimal("0.1").Rat(), Decimal("0.2").Rat()), 8))
+}
@@ -0,0 +1,253 @@
+package apiv2
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/bitbandi/go-nicehash-api"
+	"time"
+)
+
+// Exchange is the client of the NiceHash crypto exchange. It shares the
+// credentials, signing and rate limits of the Client that made it.
+type Exchange struct {
+	client *Client
+}
+
+// Exchange returns the exchange sub-client.
+func (client *Client) Exchange() *Exchange {
+	return &Exchange{client: client}
+}
+
+// MicroTimestamp is a point in time sent as microseconds since the epoch,
+// the exchange uses it instead of Timestamp.
+type MicroTimestamp struct {
+	time.Time
+}
+
+func (t *MicroTimestamp) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	var micros json.Number
+	if err := json.Unmarshal(data, &micros); err != nil {
+		return err
+	}
+	if micros == "" {
+		t.Time = time.Time{}
+		return nil
+	}
+	value, err := micros.Int64()
+	if err != nil {
+		return err
+	}
+	t.Time = time.UnixMicro(value).UTC()
+	return nil
+}
+
+func (t MicroTimestamp) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.UnixMicro())
+}
+
+// ExchangeMarket is a trading pair of the exchange, eg. "LTCBTC".
+type ExchangeMarket struct {
+	Symbol              string  `json:"symbol"`
+	Status              string  `json:"status"`
+	BaseAsset           string  `json:"baseAsset"`
+	BaseAssetPrecision  int     `json:"baseAssetPrecision"`
+	QuoteAsset          string  `json:"quoteAsset"`
+	QuotePrecision      int     `json:"quotePrecision"`
+	PriceStep           Decimal `json:"priceStep"`
+	BaseAssetMinAmount  Decimal `json:"baseAssetMinAmount"`
+	BaseAssetMaxAmount  Decimal `json:"baseAssetMaxAmount"`
+	QuoteAssetMinAmount Decimal `json:"quoteAssetMinAmount"`
+	QuoteAssetMaxAmount Decimal `json:"quoteAssetMaxAmount"`
+}
+
+// Markets returns the trading pairs of the exchange.
+func (e *Exchange) Markets(ctx context.Context) ([]ExchangeMarket, error) {
+	markets := &struct {
+		Symbols []ExchangeMarket `json:"symbols"`
+	}{}
+	_, err := e.client.receive(ctx, e.client.sling.New().Get("exchange/api/v2/info/status"), nicehash.RateLimitPublic, markets)
+	if err != nil {
+		return nil, err
+	}
+	return markets.Symbols, nil
+}
+
+// DepthEntry is a price level of the order book.
+type DepthEntry struct {
+	Price    Decimal
+	Quantity Decimal
+}
+
+// UnmarshalJSON accepts the [price, quantity] pairs of the api.
+func (d *DepthEntry) UnmarshalJSON(data []byte) error {
+	var pair []Decimal
+	if err := json.Unmarshal(data, &pair); err != nil {
+		return err
+	}
+	if len(pair) != 2 {
+		return fmt.Errorf("nicehash: order book entry %s is not a price and quantity pair", data)
+	}
+	d.Price, d.Quantity = pair[0], pair[1]
+	return nil
+}
+
+type ExchangeOrderBook struct {
+	Tick      int64          `json:"tick"`
+	UpdatedTs MicroTimestamp `json:"updatedTs"`
+	// Sell is sorted by ascending price, Buy by descending price.
+	Sell []DepthEntry `json:"sell"`
+	Buy  []DepthEntry `json:"buy"`
+}
+
+// OrderBook returns the depth of market, at most limit levels per side
+// (zero means the default of the server).
+func (e *Exchange) OrderBook(ctx context.Context, market string, limit int) (ExchangeOrderBook, error) {
+	book := ExchangeOrderBook{}
+	params := &struct {
+		Market string `url:"market"`
+		Limit  int    `url:"limit,omitempty"`
+	}{market, limit}
+	_, err := e.client.receive(ctx, e.client.sling.New().Get("exchange/api/v2/orderbook").QueryStruct(params), nicehash.RateLimitPublic, &book)
+	return book, err
+}
+
+type OrderSide string
+
+const (
+	OrderSideBuy  OrderSide = "BUY"
+	OrderSideSell OrderSide = "SELL"
+)
+
+type Trade struct {
+	Id        string         `json:"id"`
+	Direction OrderSide      `json:"dir"`
+	Price     Decimal        `json:"price"`
+	Quantity  Decimal        `json:"qty"`
+	Total     Decimal        `json:"sndQty"`
+	Time      MicroTimestamp `json:"time"`
+}
+
+// Trades returns the last trades of market, newest first.
+func (e *Exchange) Trades(ctx context.Context, market string, limit int) ([]Trade, error) {
+	var trades []Trade
+	params := &struct {
+		Market string `url:"market"`
+		Limit  int    `url:"limit,omitempty"`
+	}{market, limit}
+	_, err := e.client.receive(ctx, e.client.sling.New().Get("exchange/api/v2/info/trades").QueryStruct(params), nicehash.RateLimitPublic, &trades)
+	return trades, err
+}
+
+// Candle is the summary of the trades of one period. Time is the start of
+// the period in seconds since the epoch.
+type Candle struct {
+	Time        int64   `json:"time"`
+	Open        Decimal `json:"open"`
+	Close       Decimal `json:"close"`
+	Low         Decimal `json:"low"`
+	High        Decimal `json:"high"`
+	Volume      Decimal `json:"volume"`
+	QuoteVolume Decimal `json:"audit_volume"`
+}
+
+// Candlesticks returns the candles of market between from and to, one per
+// resolution (rounded down to minutes).
+func (e *Exchange) Candlesticks(ctx context.Context, market string, from time.Time, to time.Time, resolution time.Duration) ([]Candle, error) {
+	var candles []Candle
+	params := &struct {
+		Market     string `url:"market"`
+		From       int64  `url:"from"`
+		To         int64  `url:"to"`
+		Resolution int64  `url:"resolution"`
+	}{market, from.Unix(), to.Unix(), int64(resolution / time.Minute)}
+	_, err := e.client.receive(ctx, e.client.sling.New().Get("exchange/api/v2/info/candlesticks").QueryStruct(params), nicehash.RateLimitPublic, &candles)
+	return candles, err
+}
+
+type ExchangeOrderType string
+
+const (
+	ExchangeOrderLimit  ExchangeOrderType = "LIMIT"
+	ExchangeOrderMarket ExchangeOrderType = "MARKET"
+)
+
+// ExchangeOrderState is the state of an exchange order, eg. "ENTERED",
+// "PARTIAL", "FULL" or "CANCELLED".
+type ExchangeOrderState string
+
+type ExchangeOrder struct {
+	OrderId          string             `json:"orderId"`
+	Price            Decimal            `json:"price"`
+	OrigQty          Decimal            `json:"origQty"`
+	OrigSndQty       Decimal            `json:"origSndQty"`
+	ExecutedQty      Decimal            `json:"executedQty"`
+	ExecutedSndQty   Decimal            `json:"executedSndQty"`
+	Type             ExchangeOrderType  `json:"type"`
+	Side             OrderSide          `json:"side"`
+	SubmitTime       MicroTimestamp     `json:"submitTime"`
+	LastResponseTime MicroTimestamp     `json:"lastResponseTime"`
+	State            ExchangeOrderState `json:"state"`
+}
+
+// MyOrders returns the last orders of the organization on market, at most
+// limit of them (zero means the default of the server).
+func (e *Exchange) MyOrders(ctx context.Context, market string, limit int) ([]ExchangeOrder, error) {
+	var orders []ExchangeOrder
+	params := &struct {
+		Market string `url:"market"`
+		Limit  int    `url:"limit,omitempty"`
+	}{market, limit}
+	_, err := e.client.receive(ctx, e.client.sling.New().Get("exchange/api/v2/info/myOrders").QueryStruct(params), nicehash.RateLimitPrivate, &orders)
+	return orders, err
+}
+
+// ExchangeNewOrder is an order to place. Quantity is in the base asset;
+// a market buy is sized by SecQuantity in the quote asset instead.
+type ExchangeNewOrder struct {
+	Market      string            `url:"market"`
+	Side        OrderSide         `url:"side"`
+	Type        ExchangeOrderType `url:"type"`
+	Quantity    Decimal           `url:"quantity,omitempty"`
+	Price       Decimal           `url:"price,omitempty"`
+	SecQuantity Decimal           `url:"secQuantity,omitempty"`
+}
+
+// PlaceOrder places an order on the exchange.
+func (e *Exchange) PlaceOrder(ctx context.Context, order ExchangeNewOrder) (ExchangeOrder, error) {
+	placed := ExchangeOrder{}
+	_, err := e.client.receive(ctx, e.client.sling.New().Post("exchange/api/v2/order").QueryStruct(&order), nicehash.RateLimitPrivate, &placed)
+	return placed, err
+}
+
+// PlaceLimitOrder buys or sells quantity of the base asset at price.
+func (e *Exchange) PlaceLimitOrder(ctx context.Context, market string, side OrderSide, quantity Decimal, price Decimal) (ExchangeOrder, error) {
+	return e.PlaceOrder(ctx, ExchangeNewOrder{Market: market, Side: side, Type: ExchangeOrderLimit, Quantity: quantity, Price: price})
+}
+
+// PlaceMarketOrder buys or sells at the best available prices. A buy
+// spends amount of the quote asset, a sell sells amount of the base asset.
+func (e *Exchange) PlaceMarketOrder(ctx context.Context, market string, side OrderSide, amount Decimal) (ExchangeOrder, error) {
+	order := ExchangeNewOrder{Market: market, Side: side, Type: ExchangeOrderMarket}
+	if side == OrderSideBuy {
+		order.SecQuantity = amount
+	} else {
+		order.Quantity = amount
+	}
+	return e.PlaceOrder(ctx, order)
+}
+
+// CancelOrder cancels an open order of market.
+func (e *Exchange) CancelOrder(ctx context.Context, market string, orderId string) (ExchangeOrder, error) {
+	order := ExchangeOrder{}
+	params := &struct {
+		Market  string `url:"market"`
+		OrderId string `url:"orderId"`
+	}{market, orderId}
+	_, err := e.client.receive(ctx, e.client.sling.New().Delete("exchange/api/v2/order").QueryStruct(params), nicehash.RateLimitPrivate, &order)
+	return order, err
+}
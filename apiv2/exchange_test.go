@@ -0,0 +1,152 @@
+package apiv2
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExchangeMarkets(t *testing.T) {
+	client, mux, server := testServer(t)
+	defer server.Close()
+
+	mux.HandleFunc("/exchange/api/v2/info/status", func(w http.ResponseWriter, r *http.Request) {
+		assert.Empty(t, r.Header.Get("X-Auth"))
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"symbols":[{"symbol":"LTCBTC","status":"TRADING","baseAsset":"LTC","baseAssetPrecision":8,"quoteAsset":"BTC","quotePrecision":8,"priceStep":0.000001,"baseAssetMinAmount":"0.01","baseAssetMaxAmount":"100000","quoteAssetMinAmount":"0.0001","quoteAssetMaxAmount":"1000"}]}`)
+	})
+
+	markets, err := client.Exchange().Markets(context.Background())
+
+	assert.Nil(t, err)
+	assert.Equal(t, []ExchangeMarket{{
+		Symbol:              "LTCBTC",
+		Status:              "TRADING",
+		BaseAsset:           "LTC",
+		BaseAssetPrecision:  8,
+		QuoteAsset:          "BTC",
+		QuotePrecision:      8,
+		PriceStep:           "0.000001",
+		BaseAssetMinAmount:  "0.01",
+		BaseAssetMaxAmount:  "100000",
+		QuoteAssetMinAmount: "0.0001",
+		QuoteAssetMaxAmount: "1000",
+	}}, markets)
+}
+
+func TestExchangeOrderBook(t *testing.T) {
+	client, mux, server := testServer(t)
+	defer server.Close()
+
+	mux.HandleFunc("/exchange/api/v2/orderbook", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "LTCBTC", r.URL.Query().Get("market"))
+		assert.Equal(t, "2", r.URL.Query().Get("limit"))
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"tick":1234,"updatedTs":1549015200000000,"sell":[[0.00710,12.5],[0.00711,3.00000001]],"buy":[[0.00709,1.1],[0.00700,40]]}`)
+	})
+
+	book, err := client.Exchange().OrderBook(context.Background(), "LTCBTC", 2)
+
+	assert.Nil(t, err)
+	assert.Equal(t, ExchangeOrderBook{
+		Tick:      1234,
+		UpdatedTs: MicroTimestamp{time.Date(2019, 2, 1, 10, 0, 0, 0, time.UTC)},
+		Sell:      []DepthEntry{{"0.00710", "12.5"}, {"0.00711", "3.00000001"}},
+		Buy:       []DepthEntry{{"0.00709", "1.1"}, {"0.00700", "40"}},
+	}, book)
+}
+
+func TestExchangeTradesAndCandles(t *testing.T) {
+	client, mux, server := testServer(t)
+	defer server.Close()
+
+	mux.HandleFunc("/exchange/api/v2/info/trades", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `[{"id":"T1","dir":"SELL","price":0.0071,"qty":2,"sndQty":0.0142,"time":1549015200000001}]`)
+	})
+	mux.HandleFunc("/exchange/api/v2/info/candlesticks", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "1549015200", r.URL.Query().Get("from"))
+		assert.Equal(t, "1549018800", r.URL.Query().Get("to"))
+		assert.Equal(t, "60", r.URL.Query().Get("resolution"))
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `[{"time":1549015200,"open":0.007,"close":0.0071,"low":0.0069,"high":0.0072,"volume":120.5,"audit_volume":0.85}]`)
+	})
+
+	trades, err := client.Exchange().Trades(context.Background(), "LTCBTC", 0)
+	assert.Nil(t, err)
+	assert.Equal(t, []Trade{{Id: "T1", Direction: OrderSideSell, Price: "0.0071", Quantity: "2", Total: "0.0142", Time: MicroTimestamp{time.Date(2019, 2, 1, 10, 0, 0, 1000, time.UTC)}}}, trades)
+
+	from := time.Date(2019, 2, 1, 10, 0, 0, 0, time.UTC)
+	candles, err := client.Exchange().Candlesticks(context.Background(), "LTCBTC", from, from.Add(time.Hour), time.Hour)
+	assert.Nil(t, err)
+	assert.Equal(t, []Candle{{Time: 1549015200, Open: "0.007", Close: "0.0071", Low: "0.0069", High: "0.0072", Volume: "120.5", QuoteVolume: "0.85"}}, candles)
+}
+
+func TestExchangeOrders(t *testing.T) {
+	client, mux, server := testServer(t)
+	defer server.Close()
+
+	sampleOrder := `{"orderId":"O1","price":"0.0071","origQty":"1.5","origSndQty":"0.01065","executedQty":"0","executedSndQty":"0","type":"LIMIT","side":"BUY","submitTime":1549015200000000,"lastResponseTime":1549015200000000,"state":"%s"}`
+	expectedOrder := ExchangeOrder{
+		OrderId:          "O1",
+		Price:            "0.0071",
+		OrigQty:          "1.5",
+		OrigSndQty:       "0.01065",
+		ExecutedQty:      "0",
+		ExecutedSndQty:   "0",
+		Type:             ExchangeOrderLimit,
+		Side:             OrderSideBuy,
+		SubmitTime:       MicroTimestamp{time.Date(2019, 2, 1, 10, 0, 0, 0, time.UTC)},
+		LastResponseTime: MicroTimestamp{time.Date(2019, 2, 1, 10, 0, 0, 0, time.UTC)},
+		State:            "ENTERED",
+	}
+
+	mux.HandleFunc("/exchange/api/v2/order", func(w http.ResponseWriter, r *http.Request) {
+		assertSigned(t, r)
+		query := r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == "DELETE" {
+			assert.Equal(t, "LTCBTC", query.Get("market"))
+			assert.Equal(t, "O1", query.Get("orderId"))
+			fmt.Fprintf(w, sampleOrder, "CANCELLED")
+			return
+		}
+		assert.Equal(t, "POST", r.Method)
+		if query.Get("type") == "MARKET" {
+			assert.Equal(t, "0.01", query.Get("secQuantity"))
+			assert.Empty(t, query.Get("quantity"))
+			assert.Empty(t, query.Get("price"))
+		} else {
+			assert.Equal(t, "LTCBTC", query.Get("market"))
+			assert.Equal(t, "BUY", query.Get("side"))
+			assert.Equal(t, "1.5", query.Get("quantity"))
+			assert.Equal(t, "0.0071", query.Get("price"))
+		}
+		fmt.Fprintf(w, sampleOrder, "ENTERED")
+	})
+	mux.HandleFunc("/exchange/api/v2/info/myOrders", func(w http.ResponseWriter, r *http.Request) {
+		assertSigned(t, r)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, "["+sampleOrder+"]", "ENTERED")
+	})
+
+	exchange := client.Exchange()
+
+	order, err := exchange.PlaceLimitOrder(context.Background(), "LTCBTC", OrderSideBuy, "1.5", "0.0071")
+	assert.Nil(t, err)
+	assert.Equal(t, expectedOrder, order)
+
+	_, err = exchange.PlaceMarketOrder(context.Background(), "LTCBTC", OrderSideBuy, "0.01")
+	assert.Nil(t, err)
+
+	orders, err := exchange.MyOrders(context.Background(), "LTCBTC", 10)
+	assert.Nil(t, err)
+	assert.Equal(t, []ExchangeOrder{expectedOrder}, orders)
+
+	order, err = exchange.CancelOrder(context.Background(), "LTCBTC", "O1")
+	assert.Nil(t, err)
+	assert.Equal(t, ExchangeOrderState("CANCELLED"), order.State)
+}
@@ -0,0 +1,341 @@
+package apiv2
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/bitbandi/go-nicehash-api"
+	"math/big"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// The hashpower marketplace replaces the v1 orders.* methods:
+// GetOrders is OrderBook, GetMyOrders is MyOrders, OrderCreate is
+// CreateOrder, OrderRefill is RefillOrder, OrderSetPrice and OrderSetLimit
+// are UpdateOrderPriceAndLimit and OrderRemove is CancelOrder.
+
+// Algorithm is the name of a mining algorithm, eg. "SCRYPT" or "DAGGERHASHIMOTO".
+type Algorithm string
+
+func (a *Algorithm) UnmarshalJSON(data []byte) error {
+	return unmarshalCode(data, "algorithm", (*string)(a))
+}
+
+type Market string
+
+const (
+	MarketEU  Market = "EU"
+	MarketUSA Market = "USA"
+)
+
+type OrderType string
+
+const (
+	OrderTypeStandard OrderType = "STANDARD"
+	OrderTypeFixed    OrderType = "FIXED"
+)
+
+func (t *OrderType) UnmarshalJSON(data []byte) error {
+	return unmarshalCode(data, "code", (*string)(t))
+}
+
+type OrderStatus string
+
+const (
+	OrderStatusPending             OrderStatus = "PENDING"
+	OrderStatusActive              OrderStatus = "ACTIVE"
+	OrderStatusPendingCancellation OrderStatus = "PENDING_CANCELLATION"
+	OrderStatusCancelled           OrderStatus = "CANCELLED"
+	OrderStatusDead                OrderStatus = "DEAD"
+	OrderStatusExpired             OrderStatus = "EXPIRED"
+	OrderStatusError               OrderStatus = "ERROR"
+	OrderStatusCompleted           OrderStatus = "COMPLETED"
+)
+
+func (s *OrderStatus) UnmarshalJSON(data []byte) error {
+	return unmarshalCode(data, "code", (*string)(s))
+}
+
+// unmarshalCode decodes a json string, or the string field key of a json
+// object, into value. The api uses both forms for enumerations, eg.
+// "ACTIVE" or {"code":"ACTIVE","description":"Active"}, the UnmarshalJSON
+// methods of the enumeration types of this package decode through it.
+func unmarshalCode(data []byte, key string, value *string) error {
+	if len(data) > 0 && data[0] == '{' {
+		var object map[string]json.RawMessage
+		if err := json.Unmarshal(data, &object); err != nil {
+			return err
+		}
+		data = object[key]
+		if data == nil {
+			*value = ""
+			return nil
+		}
+	}
+	return json.Unmarshal(data, value)
+}
+
+// MarketOrderBook is the order book of an algorithm on one market.
+type MarketOrderBook struct {
+	UpdatedTs           time.Time   `json:"updatedTs"`
+	TotalSpeed          Decimal     `json:"totalSpeed"`
+	MarketFactor        Decimal     `json:"marketFactor"`
+	DisplayMarketFactor string      `json:"displayMarketFactor"`
+	PriceFactor         Decimal     `json:"priceFactor"`
+	DisplayPriceFactor  string      `json:"displayPriceFactor"`
+	Orders              []BookOrder `json:"orders"`
+}
+
+type BookOrder struct {
+	Id            string    `json:"id"`
+	Type          OrderType `json:"type"`
+	Price         Decimal   `json:"price"`
+	Limit         Decimal   `json:"limit"`
+	RigsCount     int       `json:"rigsCount"`
+	AcceptedSpeed Decimal   `json:"acceptedSpeed"`
+	PayingSpeed   Decimal   `json:"payingSpeed"`
+	Alive         bool      `json:"alive"`
+}
+
+// OrderBook returns the order book of algorithm on every market.
+func (client *Client) OrderBook(ctx context.Context, algorithm Algorithm) (map[Market]MarketOrderBook, error) {
+	book := &struct {
+		Stats map[Market]MarketOrderBook `json:"stats"`
+	}{}
+	params := &struct {
+		Algorithm Algorithm `url:"algorithm"`
+		Page      int       `url:"page"`
+		Size      int       `url:"size"`
+	}{Algorithm: algorithm, Size: 1000}
+	_, err := client.receive(ctx, client.sling.New().Get("main/api/v2/hashpower/orderBook").QueryStruct(params), nicehash.RateLimitPublic, book)
+	if err != nil {
+		return nil, err
+	}
+	return book.Stats, nil
+}
+
+// Order is an order of the organization.
+type Order struct {
+	Id                   string      `json:"id"`
+	Type                 OrderType   `json:"type"`
+	Market               Market      `json:"market"`
+	Algorithm            Algorithm   `json:"algorithm"`
+	Status               OrderStatus `json:"status"`
+	Alive                bool        `json:"alive"`
+	Price                Decimal     `json:"price"`
+	Limit                Decimal     `json:"limit"`
+	Amount               Decimal     `json:"amount"`
+	AvailableAmount      Decimal     `json:"availableAmount"`
+	PayedAmount          Decimal     `json:"payedAmount"`
+	AcceptedCurrentSpeed Decimal     `json:"acceptedCurrentSpeed"`
+	RigsCount            int         `json:"rigsCount"`
+	DisplayMarketFactor  string      `json:"displayMarketFactor"`
+	MarketFactor         Decimal     `json:"marketFactor"`
+	Pool                 Pool        `json:"pool"`
+	StartTs              time.Time   `json:"startTs"`
+	EndTs                time.Time   `json:"endTs"`
+	UpdatedTs            time.Time   `json:"updatedTs"`
+}
+
+// MyOrdersQuery selects the orders returned by MyOrders.
+type MyOrdersQuery struct {
+	Algorithm Algorithm `url:"algorithm,omitempty"`
+	Market    Market    `url:"market,omitempty"`
+	Status    string    `url:"status,omitempty"`
+	// Active selects the active (true) or the historical (false) orders,
+	// nil means both.
+	Active *bool `url:"active,omitempty"`
+	// Until and Limit page backwards in time: orders created at or before
+	// Until (zero means now), at most Limit (zero means 100) of them.
+	Until time.Time `url:"-"`
+	Limit int       `url:"limit"`
+}
+
+// MyOrders returns the orders of the organization selected by query.
+func (client *Client) MyOrders(ctx context.Context, query MyOrdersQuery) ([]Order, error) {
+	orders := &struct {
+		List []Order `json:"list"`
+	}{}
+	until := query.Until
+	if until.IsZero() {
+		until = time.Now()
+	}
+	if query.Limit == 0 {
+		query.Limit = 100
+	}
+	page := &struct {
+		Ts string `url:"ts"`
+		Op string `url:"op"`
+	}{Ts: strconv.FormatInt(until.UnixMilli(), 10), Op: "LE"}
+	_, err := client.receive(ctx, client.sling.New().Get("main/api/v2/hashpower/myOrders").QueryStruct(query).QueryStruct(page), nicehash.RateLimitPrivate, orders)
+	if err != nil {
+		return nil, err
+	}
+	return orders.List, nil
+}
+
+// MyActiveOrders returns the active orders of algorithm on market.
+func (client *Client) MyActiveOrders(ctx context.Context, algorithm Algorithm, market Market) ([]Order, error) {
+	active := true
+	return client.MyOrders(ctx, MyOrdersQuery{Algorithm: algorithm, Market: market, Active: &active})
+}
+
+// Order returns an order of the organization.
+func (client *Client) Order(ctx context.Context, id string) (Order, error) {
+	order := Order{}
+	_, err := client.receive(ctx, client.sling.New().Get("main/api/v2/hashpower/order/"+url.PathEscape(id)), nicehash.RateLimitPrivate, &order)
+	return order, err
+}
+
+type NewOrder struct {
+	Market              Market    `json:"market"`
+	Algorithm           Algorithm `json:"algorithm"`
+	Type                OrderType `json:"type"`
+	PoolId              string    `json:"poolId"`
+	Amount              Decimal   `json:"amount"`
+	Price               Decimal   `json:"price"`
+	Limit               Decimal   `json:"limit"`
+	DisplayMarketFactor string    `json:"displayMarketFactor"`
+	MarketFactor        Decimal   `json:"marketFactor"`
+}
+
+// CreateOrder places a new order.
+func (client *Client) CreateOrder(ctx context.Context, newOrder NewOrder) (Order, error) {
+	order := Order{}
+	_, err := client.receive(ctx, client.sling.New().Post("main/api/v2/hashpower/order").BodyJSON(newOrder), nicehash.RateLimitPrivate, &order)
+	return order, err
+}
+
+// RefillOrder adds amount to the available amount of an order.
+func (client *Client) RefillOrder(ctx context.Context, id string, amount Decimal) (Order, error) {
+	order := Order{}
+	body := &struct {
+		Amount Decimal `json:"amount"`
+	}{Amount: amount}
+	_, err := client.receive(ctx, client.sling.New().Post("main/api/v2/hashpower/order/"+url.PathEscape(id)+"/refill").BodyJSON(body), nicehash.RateLimitPrivate, &order)
+	return order, err
+}
+
+type FixedPriceQuote struct {
+	// FixedMax is the largest speed limit available for a fixed order.
+	FixedMax Decimal `json:"fixedMax"`
+	// FixedPrice is the current price of a fixed order of the limit.
+	FixedPrice Decimal `json:"fixedPrice"`
+}
+
+// FixedPrice returns the current price of a fixed order of algorithm on
+// market with the speed limit limit.
+func (client *Client) FixedPrice(ctx context.Context, algorithm Algorithm, market Market, limit Decimal) (FixedPriceQuote, error) {
+	quote := FixedPriceQuote{}
+	body := &struct {
+		Algorithm Algorithm `json:"algorithm"`
+		Market    Market    `json:"market"`
+		Limit     Decimal   `json:"limit"`
+	}{algorithm, market, limit}
+	_, err := client.receive(ctx, client.sling.New().Post("main/api/v2/hashpower/orders/fixedPrice").BodyJSON(body), nicehash.RateLimitPrivate, &quote)
+	return quote, err
+}
+
+// MiningAlgorithm is the settings of an algorithm of the marketplace. The
+// speeds and the prices of the algorithm are in DisplayMarketFactor, ie.
+// MarketFactor hashes per second.
+type MiningAlgorithm struct {
+	Algorithm           Algorithm `json:"algorithm"`
+	Title               string    `json:"title"`
+	Enabled             bool      `json:"enabled"`
+	DisplayMarketFactor string    `json:"displayMarketFactor"`
+	MarketFactor        Decimal   `json:"marketFactor"`
+	MinimalOrderAmount  Decimal   `json:"minimalOrderAmount"`
+	MinSpeedLimit       Decimal   `json:"minSpeedLimit"`
+	MaxSpeedLimit       Decimal   `json:"maxSpeedLimit"`
+	PriceDownStep       Decimal   `json:"priceDownStep"`
+}
+
+// MiningAlgorithms returns the settings of every algorithm.
+func (client *Client) MiningAlgorithms(ctx context.Context) ([]MiningAlgorithm, error) {
+	algorithms := &struct {
+		MiningAlgorithms []MiningAlgorithm `json:"miningAlgorithms"`
+	}{}
+	_, err := client.receive(ctx, client.sling.New().Get("main/api/v2/mining/algorithms"), nicehash.RateLimitPublic, algorithms)
+	if err != nil {
+		return nil, err
+	}
+	return algorithms.MiningAlgorithms, nil
+}
+
+// marketFactor returns the hashes per second of the unit of algorithm.
+func (client *Client) marketFactor(ctx context.Context, algorithm Algorithm) (*big.Rat, error) {
+	algorithms, err := client.MiningAlgorithms(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range algorithms {
+		if a.Algorithm == algorithm && a.MarketFactor.Rat().Sign() > 0 {
+			return a.MarketFactor.Rat(), nil
+		}
+	}
+	return nil, fmt.Errorf("nicehash: no market factor of algorithm %s", algorithm)
+}
+
+var _ nicehash.FixedPriceQuoter = (*Client)(nil)
+
+// FixedPriceQuote quotes a fixed order of the v1 api, the client is the
+// quoter of nicehash.NicehashClient.GetFixedPrice, see
+// nicehash.WithFixedPriceQuoter. The units of the v1 and the v2 api differ,
+// eg. MH/s and TH/s for DaggerHashimoto, the speeds and the price are
+// converted through the market factor of the algorithm.
+func (client *Client) FixedPriceQuote(ctx context.Context, algo nicehash.AlgoType, location nicehash.Location, limit nicehash.SpeedLimit) (nicehash.FixedPriceQuote, error) {
+	algorithm, err := AlgorithmFromV1(algo)
+	if err != nil {
+		return nicehash.FixedPriceQuote{}, err
+	}
+	market, err := MarketFromV1(location)
+	if err != nil {
+		return nicehash.FixedPriceQuote{}, err
+	}
+	factor, err := client.marketFactor(ctx, algorithm)
+	if err != nil {
+		return nicehash.FixedPriceQuote{}, err
+	}
+	// scale is the v2 units in one v1 unit
+	scale := new(big.Rat).Quo(new(big.Rat).SetFloat64(float64(algo.Unit())), factor)
+	v2limit := new(big.Rat).Mul(Decimal(limit.String()).Rat(), scale)
+	quote, err := client.FixedPrice(ctx, algorithm, market, exactDecimal(v2limit))
+	if err != nil {
+		return nicehash.FixedPriceQuote{}, err
+	}
+	v1max := new(big.Rat).Quo(quote.FixedMax.Rat(), scale)
+	fixedMax, err := nicehash.ParseSpeedLimit(v1max.FloatString(8))
+	if err != nil {
+		return nicehash.FixedPriceQuote{}, err
+	}
+	v1price := new(big.Rat).Mul(quote.FixedPrice.Rat(), scale)
+	price, err := nicehash.ParseAmount(v1price.FloatString(8))
+	if err != nil {
+		return nicehash.FixedPriceQuote{}, err
+	}
+	return nicehash.FixedPriceQuote{FixedMax: fixedMax, Price: price}, nil
+}
+
+type OrderUpdate struct {
+	Price               Decimal `json:"price"`
+	Limit               Decimal `json:"limit"`
+	DisplayMarketFactor string  `json:"displayMarketFactor"`
+	MarketFactor        Decimal `json:"marketFactor"`
+}
+
+// UpdateOrderPriceAndLimit changes the price and the speed limit of an order.
+func (client *Client) UpdateOrderPriceAndLimit(ctx context.Context, id string, update OrderUpdate) (Order, error) {
+	order := Order{}
+	_, err := client.receive(ctx, client.sling.New().Post("main/api/v2/hashpower/order/"+url.PathEscape(id)+"/updatePriceAndLimit").BodyJSON(update), nicehash.RateLimitPrivate, &order)
+	return order, err
+}
+
+// CancelOrder cancels an order, the unspent amount returns to the balance.
+func (client *Client) CancelOrder(ctx context.Context, id string) (Order, error) {
+	order := Order{}
+	_, err := client.receive(ctx, client.sling.New().Delete("main/api/v2/hashpower/order/"+url.PathEscape(id)), nicehash.RateLimitPrivate, &order)
+	return order, err
+}
@@ -0,0 +1,321 @@
+package apiv2
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/bitbandi/go-nicehash-api"
+	"net/http"
+	"testing"
+	"time"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrderBook(t *testing.T) {
+	client, mux, server := testServer(t)
+	defer server.Close()
+
+	sampleItem := `{
+	   "stats":{
+	      "EU":{
+		 "updatedTs":"2019-02-01T10:00:00.000Z",
+		 "totalSpeed":"12.5",
+		 "marketFactor":"1000000000000.00000000",
+		 "displayMarketFactor":"TH",
+		 "priceFactor":"1000000000000.00000000",
+		 "displayPriceFactor":"TH",
+		 "orders":[
+		    {
+		       "id":"0b1a8f0f-8c29-4cf8-9d04-a9d36b68a2b2",
+		       "type":"STANDARD",
+		       "price":"0.0505",
+		       "limit":"1.0",
+		       "rigsCount":3,
+		       "acceptedSpeed":"0.98",
+		       "payingSpeed":"0.97",
+		       "alive":true
+		    }
+		 ]
+	      }
+	   }
+	}`
+
+	expectedItem := map[Market]MarketOrderBook{
+		MarketEU: {
+			UpdatedTs:           time.Date(2019, 2, 1, 10, 0, 0, 0, time.UTC),
+			TotalSpeed:          "12.5",
+			MarketFactor:        "1000000000000.00000000",
+			DisplayMarketFactor: "TH",
+			PriceFactor:         "1000000000000.00000000",
+			DisplayPriceFactor:  "TH",
+			Orders: []BookOrder{
+				{
+					Id:            "0b1a8f0f-8c29-4cf8-9d04-a9d36b68a2b2",
+					Type:          OrderTypeStandard,
+					Price:         "0.0505",
+					Limit:         "1.0",
+					RigsCount:     3,
+					AcceptedSpeed: "0.98",
+					PayingSpeed:   "0.97",
+					Alive:         true,
+				},
+			},
+		},
+	}
+
+	mux.HandleFunc("/main/api/v2/hashpower/orderBook", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "SHA256", r.URL.Query().Get("algorithm"))
+		assert.Empty(t, r.Header.Get("X-Auth"))
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, sampleItem)
+	})
+
+	book, err := client.OrderBook(context.Background(), "SHA256")
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedItem, book)
+}
+
+func TestMyOrders(t *testing.T) {
+	client, mux, server := testServer(t)
+	defer server.Close()
+
+	sampleItem := `{
+	   "list":[
+	      {
+		 "id":"ba5f2a8e-2b3c-4f47-92a5-9a0c6a1f0b11",
+		 "type":{"code":"STANDARD","description":"Standard"},
+		 "market":"EU",
+		 "algorithm":{"algorithm":"SCRYPT","title":"Scrypt"},
+		 "status":{"code":"ACTIVE","description":"Active"},
+		 "alive":true,
+		 "price":"1.0000",
+		 "limit":"0.0",
+		 "amount":"0.02",
+		 "availableAmount":"0.01751439",
+		 "payedAmount":"0.00248561",
+		 "acceptedCurrentSpeed":"0.0",
+		 "rigsCount":0,
+		 "displayMarketFactor":"TH",
+		 "marketFactor":"1000000000000",
+		 "pool":{
+		    "id":"8a4f2c6e-1111-2222-3333-444455556666",
+		    "name":"testpool",
+		    "algorithm":"SCRYPT",
+		    "stratumHostname":"testpool.com",
+		    "stratumPort":3333,
+		    "username":"worker",
+		    "password":"x"
+		 },
+		 "startTs":"2019-02-01T10:00:00Z",
+		 "endTs":"2019-02-11T10:00:00Z",
+		 "updatedTs":"2019-02-01T12:00:00Z"
+	      }
+	   ]
+	}`
+
+	expectedItem := []Order{
+		{
+			Id:                   "ba5f2a8e-2b3c-4f47-92a5-9a0c6a1f0b11",
+			Type:                 OrderTypeStandard,
+			Market:               MarketEU,
+			Algorithm:            "SCRYPT",
+			Status:               OrderStatusActive,
+			Alive:                true,
+			Price:                "1.0000",
+			Limit:                "0.0",
+			Amount:               "0.02",
+			AvailableAmount:      "0.01751439",
+			PayedAmount:          "0.00248561",
+			AcceptedCurrentSpeed: "0.0",
+			DisplayMarketFactor:  "TH",
+			MarketFactor:         "1000000000000",
+			Pool: Pool{
+				Id:              "8a4f2c6e-1111-2222-3333-444455556666",
+				Name:            "testpool",
+				Algorithm:       "SCRYPT",
+				StratumHostname: "testpool.com",
+				StratumPort:     3333,
+				Username:        "worker",
+				Password:        "x",
+			},
+			StartTs:   time.Date(2019, 2, 1, 10, 0, 0, 0, time.UTC),
+			EndTs:     time.Date(2019, 2, 11, 10, 0, 0, 0, time.UTC),
+			UpdatedTs: time.Date(2019, 2, 1, 12, 0, 0, 0, time.UTC),
+		},
+	}
+
+	mux.HandleFunc("/main/api/v2/hashpower/myOrders", func(w http.ResponseWriter, r *http.Request) {
+		assertSigned(t, r)
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "SCRYPT", r.URL.Query().Get("algorithm"))
+		assert.Equal(t, "EU", r.URL.Query().Get("market"))
+		assert.Equal(t, "true", r.URL.Query().Get("active"))
+		assert.Equal(t, "LE", r.URL.Query().Get("op"))
+		assert.Equal(t, "100", r.URL.Query().Get("limit"))
+		assert.NotEmpty(t, r.URL.Query().Get("ts"))
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, sampleItem)
+	})
+
+	orders, err := client.MyActiveOrders(context.Background(), "SCRYPT", MarketEU)
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedItem, orders)
+}
+
+func TestCreateOrder(t *testing.T) {
+	client, mux, server := testServer(t)
+	defer server.Close()
+
+	mux.HandleFunc("/main/api/v2/hashpower/order", func(w http.ResponseWriter, r *http.Request) {
+		assertSigned(t, r)
+		assert.Equal(t, "POST", r.Method)
+		var body map[string]interface{}
+		assert.Nil(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, map[string]interface{}{
+			"market":              "EU",
+			"algorithm":           "SHA256",
+			"type":                "FIXED",
+			"poolId":              "POOLID",
+			"amount":              "0.005",
+			"price":               "0.0505",
+			"limit":               "1.5",
+			"displayMarketFactor": "TH",
+			"marketFactor":        "1000000000000",
+		}, body)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"id":"NEWID","type":{"code":"FIXED"},"status":{"code":"PENDING"},"price":"0.0505"}`)
+	})
+
+	order, err := client.CreateOrder(context.Background(), NewOrder{
+		Market:              MarketEU,
+		Algorithm:           "SHA256",
+		Type:                OrderTypeFixed,
+		PoolId:              "POOLID",
+		Amount:              "0.005",
+		Price:               "0.0505",
+		Limit:               "1.5",
+		DisplayMarketFactor: "TH",
+		MarketFactor:        "1000000000000",
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, Order{Id: "NEWID", Type: OrderTypeFixed, Status: OrderStatusPending, Price: "0.0505"}, order)
+}
+
+func TestRefillOrder(t *testing.T) {
+	client, mux, server := testServer(t)
+	defer server.Close()
+
+	mux.HandleFunc("/main/api/v2/hashpower/order/ID/refill", func(w http.ResponseWriter, r *http.Request) {
+		assertSigned(t, r)
+		assert.Equal(t, "POST", r.Method)
+		var body map[string]string
+		assert.Nil(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, map[string]string{"amount": "0.01"}, body)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"id":"ID","availableAmount":"0.02"}`)
+	})
+
+	order, err := client.RefillOrder(context.Background(), "ID", "0.01")
+
+	assert.Nil(t, err)
+	assert.Equal(t, Decimal("0.02"), order.AvailableAmount)
+}
+
+func TestUpdateOrderPriceAndLimit(t *testing.T) {
+	client, mux, server := testServer(t)
+	defer server.Close()
+
+	mux.HandleFunc("/main/api/v2/hashpower/order/ID/updatePriceAndLimit", func(w http.ResponseWriter, r *http.Request) {
+		assertSigned(t, r)
+		assert.Equal(t, "POST", r.Method)
+		var body map[string]string
+		assert.Nil(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, map[string]string{"price": "2.1", "limit": "1", "displayMarketFactor": "TH", "marketFactor": "1000000000000"}, body)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"id":"ID","price":"2.1","limit":"1"}`)
+	})
+
+	order, err := client.UpdateOrderPriceAndLimit(context.Background(), "ID", OrderUpdate{
+		Price:               "2.1",
+		Limit:               "1",
+		DisplayMarketFactor: "TH",
+		MarketFactor:        "1000000000000",
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, Order{Id: "ID", Price: "2.1", Limit: "1"}, order)
+}
+
+func TestCancelOrder(t *testing.T) {
+	client, mux, server := testServer(t)
+	defer server.Close()
+
+	mux.HandleFunc("/main/api/v2/hashpower/order/ID", func(w http.ResponseWriter, r *http.Request) {
+		assertSigned(t, r)
+		assert.Equal(t, "DELETE", r.Method)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"id":"ID","status":{"code":"CANCELLED"}}`)
+	})
+
+	order, err := client.CancelOrder(context.Background(), "ID")
+
+	assert.Nil(t, err)
+	assert.Equal(t, Order{Id: "ID", Status: OrderStatusCancelled}, order)
+}
+
+func TestFixedPrice(t *testing.T) {
+	client, mux, server := testServer(t)
+	defer server.Close()
+
+	mux.HandleFunc("/main/api/v2/hashpower/orders/fixedPrice", func(w http.ResponseWriter, r *http.Request) {
+		assertSigned(t, r)
+		assert.Equal(t, "POST", r.Method)
+		var body map[string]string
+		assert.Nil(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, map[string]string{"algorithm": "SHA256", "market": "EU", "limit": "0.5"}, body)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"fixedMax":"12.3","fixedPrice":"0.0612"}`)
+	})
+
+	quote, err := client.FixedPrice(context.Background(), "SHA256", MarketEU, "0.5")
+
+	assert.Nil(t, err)
+	assert.Equal(t, FixedPriceQuote{FixedMax: "12.3", FixedPrice: "0.0612"}, quote)
+}
+
+func TestFixedPriceQuote(t *testing.T) {
+	client, mux, server := testServer(t)
+	defer server.Close()
+
+	mux.HandleFunc("/main/api/v2/mining/algorithms", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"miningAlgorithms":[
+			{"algorithm":"SHA256","title":"SHA256","enabled":true,"displayMarketFactor":"PH","marketFactor":"1000000000000000"},
+			{"algorithm":"DAGGERHASHIMOTO","title":"DaggerHashimoto","enabled":true,"displayMarketFactor":"TH","marketFactor":"1000000000000"}
+		]}`)
+	})
+	mux.HandleFunc("/main/api/v2/hashpower/orders/fixedPrice", func(w http.ResponseWriter, r *http.Request) {
+		assertSigned(t, r)
+		var body map[string]string
+		assert.Nil(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, map[string]string{"algorithm": "DAGGERHASHIMOTO", "market": "USA", "limit": "0.0005"}, body)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"fixedMax":"0.0123","fixedPrice":"0.85"}`)
+	})
+
+	v1, err := nicehash.New(nicehash.WithFixedPriceQuoter(client))
+	assert.Nil(t, err)
+	// 500 MH/s, the v2 api quotes DaggerHashimoto in TH/s
+	quote, err := v1.GetFixedPrice(nicehash.AlgoTypeDaggerHashimoto, nicehash.LocationWestHash, 50000000000)
+
+	assert.Nil(t, err)
+	assert.Equal(t, nicehash.FixedPriceQuote{FixedMax: 1230000000000, Price: 85}, quote)
+
+	_, err = v1.GetFixedPrice(nicehash.AlgoTypeScrypt, nicehash.LocationNiceHash, 100000000)
+	assert.EqualError(t, err, "nicehash: no market factor of algorithm SCRYPT")
+}
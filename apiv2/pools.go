@@ -0,0 +1,178 @@
+package apiv2
+
+import (
+	"context"
+	"fmt"
+	"github.com/bitbandi/go-nicehash-api"
+	"net/url"
+	"strings"
+)
+
+// Pool is a saved mining pool the orders send the hashpower to.
+type Pool struct {
+	Id              string    `json:"id,omitempty"`
+	Name            string    `json:"name"`
+	Algorithm       Algorithm `json:"algorithm"`
+	StratumHostname string    `json:"stratumHostname"`
+	StratumPort     uint16    `json:"stratumPort"`
+	Username        string    `json:"username"`
+	Password        string    `json:"password"`
+}
+
+// Pagination is the page information of the paged lists.
+type Pagination struct {
+	Size           int `json:"size"`
+	Page           int `json:"page"`
+	TotalPageCount int `json:"totalPageCount"`
+}
+
+// Pools returns every saved pool of the organization.
+func (client *Client) Pools(ctx context.Context) ([]Pool, error) {
+	var all []Pool
+	for page := 0; ; page++ {
+		pools := &struct {
+			List       []Pool     `json:"list"`
+			Pagination Pagination `json:"pagination"`
+		}{}
+		params := &struct {
+			Page int `url:"page"`
+			Size int `url:"size"`
+		}{Page: page, Size: 100}
+		_, err := client.receive(ctx, client.sling.New().Get("main/api/v2/pools").QueryStruct(params), nicehash.RateLimitPrivate, pools)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, pools.List...)
+		if len(pools.List) == 0 || page+1 >= pools.Pagination.TotalPageCount {
+			return all, nil
+		}
+	}
+}
+
+// Pool returns a saved pool.
+func (client *Client) Pool(ctx context.Context, id string) (Pool, error) {
+	pool := Pool{}
+	_, err := client.receive(ctx, client.sling.New().Get("main/api/v2/pool/"+url.PathEscape(id)), nicehash.RateLimitPrivate, &pool)
+	return pool, err
+}
+
+// CreatePool saves a new pool, the Id of pool must be empty.
+func (client *Client) CreatePool(ctx context.Context, pool Pool) (Pool, error) {
+	if pool.Id != "" {
+		return Pool{}, fmt.Errorf("nicehash: pool %s already exists", pool.Id)
+	}
+	created := Pool{}
+	_, err := client.receive(ctx, client.sling.New().Post("main/api/v2/pool").BodyJSON(pool), nicehash.RateLimitPrivate, &created)
+	return created, err
+}
+
+// UpdatePool modifies the saved pool identified by the Id of pool.
+func (client *Client) UpdatePool(ctx context.Context, pool Pool) (Pool, error) {
+	if pool.Id == "" {
+		return Pool{}, fmt.Errorf("nicehash: missing pool id")
+	}
+	updated := Pool{}
+	_, err := client.receive(ctx, client.sling.New().Post("main/api/v2/pool").BodyJSON(pool), nicehash.RateLimitPrivate, &updated)
+	return updated, err
+}
+
+// DeletePool removes a saved pool.
+func (client *Client) DeletePool(ctx context.Context, id string) error {
+	_, err := client.receive(ctx, client.sling.New().Delete("main/api/v2/pool/"+url.PathEscape(id)), nicehash.RateLimitPrivate, nil)
+	return err
+}
+
+type PoolVerification struct {
+	Success bool   `json:"success"`
+	Logs    string `json:"logs"`
+}
+
+// VerifyPool asks the servers of market to connect to pool and reports
+// whether it accepts the hashpower.
+func (client *Client) VerifyPool(ctx context.Context, pool Pool, market Market) (PoolVerification, error) {
+	verification := PoolVerification{}
+	body := &struct {
+		Location        Market    `json:"poolVerificationServiceLocation"`
+		Algorithm       Algorithm `json:"algorithm"`
+		StratumHostname string    `json:"stratumHost"`
+		StratumPort     uint16    `json:"stratumPort"`
+		Username        string    `json:"username"`
+		Password        string    `json:"password"`
+	}{market, pool.Algorithm, pool.StratumHostname, pool.StratumPort, pool.Username, pool.Password}
+	_, err := client.receive(ctx, client.sling.New().Post("main/api/v2/pools/verify").BodyJSON(body), nicehash.RateLimitPrivate, &verification)
+	return verification, err
+}
+
+// AlgorithmFromV1 returns the v2 name of a v1 algorithm.
+func AlgorithmFromV1(algo nicehash.AlgoType) (Algorithm, error) {
+	name := algo.ToString()
+	if name == "NA" {
+		return "", fmt.Errorf("nicehash: unknown algorithm %d", algo)
+	}
+	return Algorithm(strings.ToUpper(name)), nil
+}
+
+// MarketFromV1 returns the market of a v1 location.
+func MarketFromV1(location nicehash.Location) (Market, error) {
+	switch location {
+	case nicehash.LocationNiceHash:
+		return MarketEU, nil
+	case nicehash.LocationWestHash:
+		return MarketUSA, nil
+	}
+	return "", fmt.Errorf("nicehash: unknown location %d", location)
+}
+
+// PoolForOrder returns the saved pool matching the inline pool of a v1
+// order, the pool is created when there is none.
+func (client *Client) PoolForOrder(ctx context.Context, order nicehash.NewOrder) (Pool, error) {
+	algorithm, err := AlgorithmFromV1(order.Algo)
+	if err != nil {
+		return Pool{}, err
+	}
+	wanted := Pool{
+		Name:            fmt.Sprintf("%s:%d/%s", order.PoolHost, order.PoolPort, order.PoolUser),
+		Algorithm:       algorithm,
+		StratumHostname: order.PoolHost,
+		StratumPort:     order.PoolPort,
+		Username:        order.PoolUser,
+		Password:        order.PoolPass,
+	}
+	pools, err := client.Pools(ctx)
+	if err != nil {
+		return Pool{}, err
+	}
+	for _, pool := range pools {
+		if pool.Algorithm == wanted.Algorithm &&
+			strings.EqualFold(pool.StratumHostname, wanted.StratumHostname) &&
+			pool.StratumPort == wanted.StratumPort &&
+			pool.Username == wanted.Username &&
+			pool.Password == wanted.Password {
+			return pool, nil
+		}
+	}
+	return client.CreatePool(ctx, wanted)
+}
+
+// NewOrderFromV1 converts a v1 order to a v2 order placed on market and
+// sending the hashpower to the saved pool poolId, see PoolForOrder.
+// The market factor fields are left for the caller to fill in.
+func NewOrderFromV1(order nicehash.NewOrder, poolId string, market Market) (NewOrder, error) {
+	algorithm, err := AlgorithmFromV1(order.Algo)
+	if err != nil {
+		return NewOrder{}, err
+	}
+	orderType := OrderTypeStandard
+	if order.Type == nicehash.OrderTypeFixed {
+		orderType = OrderTypeFixed
+	}
+	return NewOrder{
+		Market:    market,
+		Algorithm: algorithm,
+		Type:      orderType,
+		PoolId:    poolId,
+		Amount:    Decimal(order.Amount.String()),
+		Price:     Decimal(order.Price.String()),
+		Limit:     Decimal(order.LimitSpeed.String()),
+	}, nil
+}
@@ -0,0 +1,170 @@
+package apiv2
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/bitbandi/go-nicehash-api"
+	"net/http"
+	"testing"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPools(t *testing.T) {
+	client, mux, server := testServer(t)
+	defer server.Close()
+
+	mux.HandleFunc("/main/api/v2/pools", func(w http.ResponseWriter, r *http.Request) {
+		assertSigned(t, r)
+		assert.Equal(t, "GET", r.Method)
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Query().Get("page") {
+		case "0":
+			fmt.Fprint(w, `{"list":[{"id":"P1","name":"one","algorithm":{"algorithm":"SCRYPT"},"stratumHostname":"one.com","stratumPort":3333,"username":"u","password":"x"}],"pagination":{"size":1,"page":0,"totalPageCount":2}}`)
+		case "1":
+			fmt.Fprint(w, `{"list":[{"id":"P2","name":"two","algorithm":"SHA256","stratumHostname":"two.com","stratumPort":4444,"username":"u","password":"x"}],"pagination":{"size":1,"page":1,"totalPageCount":2}}`)
+		default:
+			t.Error("unexpected page")
+		}
+	})
+
+	pools, err := client.Pools(context.Background())
+
+	assert.Nil(t, err)
+	assert.Equal(t, []Pool{
+		{Id: "P1", Name: "one", Algorithm: "SCRYPT", StratumHostname: "one.com", StratumPort: 3333, Username: "u", Password: "x"},
+		{Id: "P2", Name: "two", Algorithm: "SHA256", StratumHostname: "two.com", StratumPort: 4444, Username: "u", Password: "x"},
+	}, pools)
+}
+
+func TestCreateUpdateDeletePool(t *testing.T) {
+	client, mux, server := testServer(t)
+	defer server.Close()
+
+	mux.HandleFunc("/main/api/v2/pool", func(w http.ResponseWriter, r *http.Request) {
+		assertSigned(t, r)
+		assert.Equal(t, "POST", r.Method)
+		var pool Pool
+		assert.Nil(t, json.NewDecoder(r.Body).Decode(&pool))
+		if pool.Id == "" {
+			pool.Id = "NEWID"
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(pool)
+	})
+	mux.HandleFunc("/main/api/v2/pool/NEWID", func(w http.ResponseWriter, r *http.Request) {
+		assertSigned(t, r)
+		assert.Equal(t, "DELETE", r.Method)
+		w.WriteHeader(http.StatusOK)
+	})
+
+	pool := Pool{Name: "test", Algorithm: "SCRYPT", StratumHostname: "testpool.com", StratumPort: 3333, Username: "worker", Password: "x"}
+	created, err := client.CreatePool(context.Background(), pool)
+	assert.Nil(t, err)
+	pool.Id = "NEWID"
+	assert.Equal(t, pool, created)
+
+	_, err = client.CreatePool(context.Background(), created)
+	assert.NotNil(t, err)
+
+	created.Password = "y"
+	updated, err := client.UpdatePool(context.Background(), created)
+	assert.Nil(t, err)
+	assert.Equal(t, created, updated)
+
+	assert.Nil(t, client.DeletePool(context.Background(), "NEWID"))
+}
+
+func TestVerifyPool(t *testing.T) {
+	client, mux, server := testServer(t)
+	defer server.Close()
+
+	mux.HandleFunc("/main/api/v2/pools/verify", func(w http.ResponseWriter, r *http.Request) {
+		assertSigned(t, r)
+		var body map[string]interface{}
+		assert.Nil(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "EU", body["poolVerificationServiceLocation"])
+		assert.Equal(t, "testpool.com", body["stratumHost"])
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"success":true,"logs":"connected"}`)
+	})
+
+	pool := Pool{Algorithm: "SCRYPT", StratumHostname: "testpool.com", StratumPort: 3333, Username: "worker", Password: "x"}
+	verification, err := client.VerifyPool(context.Background(), pool, MarketEU)
+
+	assert.Nil(t, err)
+	assert.Equal(t, PoolVerification{Success: true, Logs: "connected"}, verification)
+}
+
+func TestPoolForOrder(t *testing.T) {
+	client, mux, server := testServer(t)
+	defer server.Close()
+
+	created := 0
+	mux.HandleFunc("/main/api/v2/pools", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"list":[{"id":"P1","name":"one","algorithm":"SCRYPT","stratumHostname":"TestPool.com","stratumPort":3333,"username":"worker","password":"x"}],"pagination":{"totalPageCount":1}}`)
+	})
+	mux.HandleFunc("/main/api/v2/pool", func(w http.ResponseWriter, r *http.Request) {
+		created++
+		var pool Pool
+		assert.Nil(t, json.NewDecoder(r.Body).Decode(&pool))
+		pool.Id = "P2"
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(pool)
+	})
+
+	order := nicehash.NewOrder{
+		Algo:     nicehash.AlgoTypeScrypt,
+		PoolHost: "testpool.com",
+		PoolPort: 3333,
+		PoolUser: "worker",
+		PoolPass: "x",
+	}
+	pool, err := client.PoolForOrder(context.Background(), order)
+	assert.Nil(t, err)
+	assert.Equal(t, "P1", pool.Id)
+	assert.Equal(t, 0, created)
+
+	order.Algo = nicehash.AlgoTypeDaggerHashimoto
+	pool, err = client.PoolForOrder(context.Background(), order)
+	assert.Nil(t, err)
+	assert.Equal(t, Pool{
+		Id:              "P2",
+		Name:            "testpool.com:3333/worker",
+		Algorithm:       "DAGGERHASHIMOTO",
+		StratumHostname: "testpool.com",
+		StratumPort:     3333,
+		Username:        "worker",
+		Password:        "x",
+	}, pool)
+	assert.Equal(t, 1, created)
+}
+
+func TestNewOrderFromV1(t *testing.T) {
+	order, err := NewOrderFromV1(nicehash.NewOrder{Algo: nicehash.AlgoTypeSHA256, Price: 5050000, Amount: 1000000, LimitSpeed: 150000000}, "P1", MarketUSA)
+
+	assert.Nil(t, err)
+	assert.Equal(t, NewOrder{
+		Market:    MarketUSA,
+		Algorithm: "SHA256",
+		Type:      OrderTypeStandard,
+		PoolId:    "P1",
+		Amount:    "0.01",
+		Price:     "0.0505",
+		Limit:     "1.5",
+	}, order)
+}
+
+func TestNewOrderFromV1Fixed(t *testing.T) {
+	order, err := NewOrderFromV1(nicehash.NewOrder{Algo: nicehash.AlgoTypeSHA256, Type: nicehash.OrderTypeFixed, Price: 6120000, Amount: 1000000, LimitSpeed: 50000000}, "P1", MarketEU)
+
+	assert.Nil(t, err)
+	assert.Equal(t, OrderTypeFixed, order.Type)
+
+	market, err := MarketFromV1(nicehash.LocationWestHash)
+	assert.Nil(t, err)
+	assert.Equal(t, MarketUSA, market)
+	_, err = MarketFromV1(nicehash.LocationMAX)
+	assert.NotNil(t, err)
+}
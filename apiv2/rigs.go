@@ -0,0 +1,205 @@
+package apiv2
+
+import (
+	"context"
+	"fmt"
+	"github.com/bitbandi/go-nicehash-api"
+	"net/url"
+)
+
+// RigStatus is the state of a rig or device, eg. "MINING" or "STOPPED".
+type RigStatus string
+
+const (
+	RigStatusMining       RigStatus = "MINING"
+	RigStatusBenchmarking RigStatus = "BENCHMARKING"
+	RigStatusStopped      RigStatus = "STOPPED"
+	RigStatusOffline      RigStatus = "OFFLINE"
+	RigStatusError        RigStatus = "ERROR"
+	RigStatusPending      RigStatus = "PENDING"
+	RigStatusDisabled     RigStatus = "DISABLED"
+	RigStatusInactive     RigStatus = "INACTIVE"
+)
+
+func (s *RigStatus) UnmarshalJSON(data []byte) error {
+	return unmarshalCode(data, "enumName", (*string)(s))
+}
+
+// DeviceType is the kind of a mining device, eg. "NVIDIA", "AMD" or "CPU".
+type DeviceType string
+
+func (t *DeviceType) UnmarshalJSON(data []byte) error {
+	return unmarshalCode(data, "enumName", (*string)(t))
+}
+
+type PowerMode string
+
+const (
+	PowerModeLow    PowerMode = "LOW"
+	PowerModeMedium PowerMode = "MEDIUM"
+	PowerModeHigh   PowerMode = "HIGH"
+)
+
+func (m *PowerMode) UnmarshalJSON(data []byte) error {
+	return unmarshalCode(data, "enumName", (*string)(m))
+}
+
+type DeviceSpeed struct {
+	Algorithm     Algorithm `json:"algorithm"`
+	Title         string    `json:"title"`
+	Speed         Decimal   `json:"speed"`
+	DisplaySuffix string    `json:"displaySuffix"`
+}
+
+type Device struct {
+	Id                   string        `json:"id"`
+	Name                 string        `json:"name"`
+	DeviceType           DeviceType    `json:"deviceType"`
+	Status               RigStatus     `json:"status"`
+	Temperature          float64       `json:"temperature"`
+	Load                 float64       `json:"load"`
+	RevolutionsPerMinute float64       `json:"revolutionsPerMinute"`
+	PowerUsage           float64       `json:"powerUsage"`
+	PowerMode            PowerMode     `json:"powerMode"`
+	Speeds               []DeviceSpeed `json:"speeds"`
+}
+
+type Rig struct {
+	RigId        string    `json:"rigId"`
+	Type         string    `json:"type"`
+	Name         string    `json:"name"`
+	StatusTime   Timestamp `json:"statusTime"`
+	MinerStatus  RigStatus `json:"minerStatus"`
+	UnpaidAmount Decimal   `json:"unpaidAmount"`
+	Devices      []Device  `json:"devices"`
+}
+
+// Rigs returns every rig of the organization with the state of its devices.
+func (client *Client) Rigs(ctx context.Context) ([]Rig, error) {
+	var all []Rig
+	for page := 0; ; page++ {
+		rigs := &struct {
+			MiningRigs []Rig      `json:"miningRigs"`
+			Pagination Pagination `json:"pagination"`
+		}{}
+		params := &struct {
+			Page int `url:"page"`
+			Size int `url:"size"`
+		}{Page: page, Size: 100}
+		_, err := client.receive(ctx, client.sling.New().Get("main/api/v2/mining/rigs2").QueryStruct(params), nicehash.RateLimitPrivate, rigs)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, rigs.MiningRigs...)
+		if len(rigs.MiningRigs) == 0 || page+1 >= rigs.Pagination.TotalPageCount {
+			return all, nil
+		}
+	}
+}
+
+// Rig returns a rig with the state of its devices.
+func (client *Client) Rig(ctx context.Context, rigId string) (Rig, error) {
+	rig := Rig{}
+	_, err := client.receive(ctx, client.sling.New().Get("main/api/v2/mining/rig2/"+url.PathEscape(rigId)), nicehash.RateLimitPrivate, &rig)
+	return rig, err
+}
+
+type RigGroup struct {
+	Id     string              `json:"id"`
+	Name   string              `json:"name"`
+	RigIds []string            `json:"rigIds"`
+	Groups map[string]RigGroup `json:"groups"`
+}
+
+// RigGroups returns the rig groups of the organization by name.
+func (client *Client) RigGroups(ctx context.Context) (map[string]RigGroup, error) {
+	groups := &struct {
+		Groups map[string]RigGroup `json:"groups"`
+	}{}
+	_, err := client.receive(ctx, client.sling.New().Get("main/api/v2/mining/groups/list"), nicehash.RateLimitPrivate, groups)
+	if err != nil {
+		return nil, err
+	}
+	return groups.Groups, nil
+}
+
+type RigAction string
+
+const (
+	RigActionStart     RigAction = "START"
+	RigActionStop      RigAction = "STOP"
+	RigActionPowerMode RigAction = "POWER_MODE"
+)
+
+// RigActionResult is the outcome of an action on one rig or device.
+type RigActionResult struct {
+	RigId    string `json:"-"`
+	DeviceId string `json:"-"`
+	Success  bool   `json:"success"`
+	Message  string `json:"message"`
+	Err      error  `json:"-"`
+}
+
+// RigCommand is an action on a rig, or on one device of it when DeviceId is set.
+type RigCommand struct {
+	RigId    string    `json:"rigId"`
+	DeviceId string    `json:"deviceId,omitempty"`
+	Action   RigAction `json:"action"`
+	Options  []string  `json:"options,omitempty"`
+}
+
+// RigCommand sends an action to a rig or device.
+func (client *Client) RigCommand(ctx context.Context, command RigCommand) (RigActionResult, error) {
+	result := RigActionResult{RigId: command.RigId, DeviceId: command.DeviceId}
+	_, err := client.receive(ctx, client.sling.New().Post("main/api/v2/mining/rigs/status2").BodyJSON(command), nicehash.RateLimitPrivate, &result)
+	if err == nil && !result.Success {
+		err = fmt.Errorf("nicehash: %s rig %s: %s", command.Action, command.RigId, result.Message)
+	}
+	result.Err = err
+	return result, err
+}
+
+// StartRig starts mining on a rig, or on one of its devices when deviceId
+// is not empty.
+func (client *Client) StartRig(ctx context.Context, rigId string, deviceId string) error {
+	_, err := client.RigCommand(ctx, RigCommand{RigId: rigId, DeviceId: deviceId, Action: RigActionStart})
+	return err
+}
+
+// StopRig stops mining on a rig, or on one of its devices when deviceId
+// is not empty.
+func (client *Client) StopRig(ctx context.Context, rigId string, deviceId string) error {
+	_, err := client.RigCommand(ctx, RigCommand{RigId: rigId, DeviceId: deviceId, Action: RigActionStop})
+	return err
+}
+
+// RestartRig stops then starts mining on a rig or device, the api has no
+// restart action.
+func (client *Client) RestartRig(ctx context.Context, rigId string, deviceId string) error {
+	if err := client.StopRig(ctx, rigId, deviceId); err != nil {
+		return err
+	}
+	return client.StartRig(ctx, rigId, deviceId)
+}
+
+// SetPowerMode changes the power mode of a rig or device.
+func (client *Client) SetPowerMode(ctx context.Context, rigId string, deviceId string, mode PowerMode) error {
+	_, err := client.RigCommand(ctx, RigCommand{RigId: rigId, DeviceId: deviceId, Action: RigActionPowerMode, Options: []string{string(mode)}})
+	return err
+}
+
+// BatchRigAction sends the same action to every rig of rigIds and returns
+// the result per rig. It stops early only when ctx is done; the failures
+// are reported in the Err field of the results.
+func (client *Client) BatchRigAction(ctx context.Context, rigIds []string, action RigAction, options ...string) []RigActionResult {
+	results := make([]RigActionResult, 0, len(rigIds))
+	for _, rigId := range rigIds {
+		if err := ctx.Err(); err != nil {
+			results = append(results, RigActionResult{RigId: rigId, Err: err})
+			continue
+		}
+		result, _ := client.RigCommand(ctx, RigCommand{RigId: rigId, Action: action, Options: options})
+		results = append(results, result)
+	}
+	return results
+}
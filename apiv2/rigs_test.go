@@ -0,0 +1,136 @@
+package apiv2
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRigs(t *testing.T) {
+	client, mux, server := testServer(t)
+	defer server.Close()
+
+	sampleItem := `{
+	   "miningRigs":[
+	      {
+		 "rigId":"RIG1",
+		 "type":"MANAGED",
+		 "name":"rig one",
+		 "statusTime":1549015200000,
+		 "minerStatus":"MINING",
+		 "unpaidAmount":"0.00012345",
+		 "devices":[
+		    {
+		       "id":"DEV1",
+		       "name":"GeForce GTX 1080 Ti",
+		       "deviceType":{"enumName":"NVIDIA","description":"Nvidia"},
+		       "status":{"enumName":"MINING","description":"Mining"},
+		       "temperature":65,
+		       "load":100,
+		       "revolutionsPerMinute":2100,
+		       "powerUsage":220.5,
+		       "powerMode":{"enumName":"HIGH"},
+		       "speeds":[{"algorithm":"DAGGERHASHIMOTO","title":"DaggerHashimoto","speed":"35.12","displaySuffix":"MH"}]
+		    }
+		 ]
+	      }
+	   ],
+	   "pagination":{"size":100,"page":0,"totalPageCount":1}
+	}`
+
+	expectedItem := []Rig{
+		{
+			RigId:        "RIG1",
+			Type:         "MANAGED",
+			Name:         "rig one",
+			StatusTime:   Timestamp{time.Date(2019, 2, 1, 10, 0, 0, 0, time.UTC)},
+			MinerStatus:  RigStatusMining,
+			UnpaidAmount: "0.00012345",
+			Devices: []Device{
+				{
+					Id:                   "DEV1",
+					Name:                 "GeForce GTX 1080 Ti",
+					DeviceType:           "NVIDIA",
+					Status:               RigStatusMining,
+					Temperature:          65,
+					Load:                 100,
+					RevolutionsPerMinute: 2100,
+					PowerUsage:           220.5,
+					PowerMode:            PowerModeHigh,
+					Speeds:               []DeviceSpeed{{Algorithm: "DAGGERHASHIMOTO", Title: "DaggerHashimoto", Speed: "35.12", DisplaySuffix: "MH"}},
+				},
+			},
+		},
+	}
+
+	mux.HandleFunc("/main/api/v2/mining/rigs2", func(w http.ResponseWriter, r *http.Request) {
+		assertSigned(t, r)
+		assert.Equal(t, "GET", r.Method)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, sampleItem)
+	})
+
+	rigs, err := client.Rigs(context.Background())
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedItem, rigs)
+}
+
+func TestRigGroups(t *testing.T) {
+	client, mux, server := testServer(t)
+	defer server.Close()
+
+	mux.HandleFunc("/main/api/v2/mining/groups/list", func(w http.ResponseWriter, r *http.Request) {
+		assertSigned(t, r)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"groups":{"farm":{"id":"G1","name":"farm","rigIds":["RIG1","RIG2"],"groups":{}}}}`)
+	})
+
+	groups, err := client.RigGroups(context.Background())
+
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]RigGroup{"farm": {Id: "G1", Name: "farm", RigIds: []string{"RIG1", "RIG2"}, Groups: map[string]RigGroup{}}}, groups)
+}
+
+func TestRigCommands(t *testing.T) {
+	client, mux, server := testServer(t)
+	defer server.Close()
+
+	var commands []RigCommand
+	mux.HandleFunc("/main/api/v2/mining/rigs/status2", func(w http.ResponseWriter, r *http.Request) {
+		assertSigned(t, r)
+		assert.Equal(t, "POST", r.Method)
+		var command RigCommand
+		assert.Nil(t, json.NewDecoder(r.Body).Decode(&command))
+		commands = append(commands, command)
+		w.Header().Set("Content-Type", "application/json")
+		if command.RigId == "BAD" {
+			fmt.Fprint(w, `{"success":false,"message":"Rig is offline"}`)
+			return
+		}
+		fmt.Fprint(w, `{"success":true,"message":"OK"}`)
+	})
+
+	assert.Nil(t, client.StartRig(context.Background(), "RIG1", ""))
+	assert.Nil(t, client.RestartRig(context.Background(), "RIG1", "DEV1"))
+	assert.Nil(t, client.SetPowerMode(context.Background(), "RIG1", "DEV1", PowerModeLow))
+	assert.Equal(t, []RigCommand{
+		{RigId: "RIG1", Action: RigActionStart},
+		{RigId: "RIG1", DeviceId: "DEV1", Action: RigActionStop},
+		{RigId: "RIG1", DeviceId: "DEV1", Action: RigActionStart},
+		{RigId: "RIG1", DeviceId: "DEV1", Action: RigActionPowerMode, Options: []string{"LOW"}},
+	}, commands)
+
+	results := client.BatchRigAction(context.Background(), []string{"RIG1", "BAD", "RIG2"}, RigActionStop)
+	assert.Len(t, results, 3)
+	assert.Nil(t, results[0].Err)
+	assert.True(t, results[0].Success)
+	assert.NotNil(t, results[1].Err)
+	assert.Equal(t, "Rig is offline", results[1].Message)
+	assert.Nil(t, results[2].Err)
+	assert.Equal(t, "RIG2", results[2].RigId)
+}
@@ -0,0 +1,183 @@
+package apiv2
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"github.com/bitbandi/go-nicehash-api"
+	"math/big"
+	"sync"
+	"time"
+)
+
+// WithdrawalPolicy is the local safety net of Withdraw. Requests outside
+// the policy are refused before they are built.
+type WithdrawalPolicy struct {
+	// AllowedAddressIds is the whitelist of the withdrawal address ids.
+	AllowedAddressIds []string
+	// DailyLimits caps the amount withdrawn per currency and UTC day.
+	// Currencies without a limit cannot be withdrawn.
+	DailyLimits map[string]Decimal
+	// ConfirmationToken must be passed to every Withdraw call.
+	ConfirmationToken string
+}
+
+// ErrWithdrawalRefused is wrapped by the errors of the refused withdrawals.
+var ErrWithdrawalRefused = errors.New("nicehash: withdrawal refused")
+
+// withdrawalGuard enforces the policy and counts the amounts withdrawn
+// today. The counters live in memory, they restart with the process.
+type withdrawalGuard struct {
+	policy WithdrawalPolicy
+	now    func() time.Time
+
+	mu   sync.Mutex
+	day  string
+	used map[string]*big.Rat
+}
+
+// WithWithdrawalPolicy enables Withdraw within the limits of policy.
+// Without it every withdrawal is refused.
+func WithWithdrawalPolicy(policy WithdrawalPolicy) Option {
+	return func(client *Client) error {
+		if policy.ConfirmationToken == "" {
+			return errors.New("nicehash: withdrawal policy without confirmation token")
+		}
+		if len(policy.AllowedAddressIds) == 0 {
+			return errors.New("nicehash: withdrawal policy without allowed addresses")
+		}
+		for currency, limit := range policy.DailyLimits {
+			if _, err := ParseDecimal(string(limit)); err != nil {
+				return fmt.Errorf("nicehash: daily limit of %s: %w", currency, err)
+			}
+		}
+		// the guard keeps its own copy, the caller cannot widen the
+		// policy after the fact
+		policy.AllowedAddressIds = append([]string(nil), policy.AllowedAddressIds...)
+		limits := make(map[string]Decimal, len(policy.DailyLimits))
+		for currency, limit := range policy.DailyLimits {
+			limits[currency] = limit
+		}
+		policy.DailyLimits = limits
+		client.withdrawals = &withdrawalGuard{policy: policy, now: time.Now, used: map[string]*big.Rat{}}
+		return nil
+	}
+}
+
+// reserve checks a withdrawal against the policy and adds amount to the
+// total of the day. It returns the day of the reservation.
+func (g *withdrawalGuard) reserve(currency string, amount Decimal, addressId string, token string) (string, error) {
+	if subtle.ConstantTimeCompare([]byte(token), []byte(g.policy.ConfirmationToken)) != 1 {
+		return "", fmt.Errorf("%w: wrong confirmation token", ErrWithdrawalRefused)
+	}
+	allowed := false
+	for _, id := range g.policy.AllowedAddressIds {
+		allowed = allowed || id == addressId
+	}
+	if !allowed {
+		return "", fmt.Errorf("%w: address %s is not whitelisted", ErrWithdrawalRefused, addressId)
+	}
+	value, err := ParseDecimal(string(amount))
+	if err != nil || value.Rat().Sign() <= 0 {
+		return "", fmt.Errorf("%w: invalid amount %q", ErrWithdrawalRefused, amount)
+	}
+	limit, ok := g.policy.DailyLimits[currency]
+	if !ok {
+		return "", fmt.Errorf("%w: no daily limit for %s", ErrWithdrawalRefused, currency)
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if day := g.now().UTC().Format("2006-01-02"); day != g.day {
+		g.day = day
+		g.used = map[string]*big.Rat{}
+	}
+	used, ok := g.used[currency]
+	if !ok {
+		used = new(big.Rat)
+	}
+	total := new(big.Rat).Add(used, value.Rat())
+	if total.Cmp(limit.Rat()) > 0 {
+		return "", fmt.Errorf("%w: %s %s exceeds the daily limit of %s (%s used)", ErrWithdrawalRefused, amount, currency, limit, used.FloatString(8))
+	}
+	g.used[currency] = total
+	return g.day, nil
+}
+
+// release gives back a reservation of a withdrawal rejected by the server.
+// The reservations of a previous day are not in the counters any more.
+func (g *withdrawalGuard) release(currency string, amount Decimal, day string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if day != g.day {
+		return
+	}
+	if used, ok := g.used[currency]; ok {
+		used.Sub(used, amount.Rat())
+	}
+}
+
+type AddressStatus string
+
+func (s *AddressStatus) UnmarshalJSON(data []byte) error {
+	return unmarshalCode(data, "code", (*string)(s))
+}
+
+type WithdrawalAddress struct {
+	Id       string        `json:"id"`
+	Type     WalletType    `json:"type"`
+	Name     string        `json:"name"`
+	Address  string        `json:"address"`
+	Currency string        `json:"currency"`
+	Status   AddressStatus `json:"status"`
+}
+
+// WithdrawalAddresses returns the saved withdrawal addresses of currency.
+func (client *Client) WithdrawalAddresses(ctx context.Context, currency string) ([]WithdrawalAddress, error) {
+	addresses := &struct {
+		List []WithdrawalAddress `json:"list"`
+	}{}
+	params := &struct {
+		Currency string `url:"currency"`
+	}{currency}
+	_, err := client.receive(ctx, client.sling.New().Get("main/api/v2/accounting/withdrawalAddresses").QueryStruct(params), nicehash.RateLimitPrivate, addresses)
+	if err != nil {
+		return nil, err
+	}
+	return addresses.List, nil
+}
+
+// Withdraw sends amount of currency to the saved withdrawal address
+// addressId and returns the id of the withdrawal. The withdrawal must
+// satisfy the WithdrawalPolicy of the client and token must be its
+// confirmation token, otherwise an error wrapping ErrWithdrawalRefused is
+// returned without contacting the server.
+func (client *Client) Withdraw(ctx context.Context, currency string, amount Decimal, addressId string, token string) (string, error) {
+	if client.withdrawals == nil {
+		return "", fmt.Errorf("%w: no withdrawal policy", ErrWithdrawalRefused)
+	}
+	day, err := client.withdrawals.reserve(currency, amount, addressId, token)
+	if err != nil {
+		return "", err
+	}
+	withdrawal := &struct {
+		Id string `json:"id"`
+	}{}
+	body := &struct {
+		Currency            string  `json:"currency"`
+		Amount              Decimal `json:"amount"`
+		WithdrawalAddressId string  `json:"withdrawalAddressId"`
+	}{currency, amount, addressId}
+	_, err = client.receive(ctx, client.sling.New().Post("main/api/v2/accounting/withdrawal").BodyJSON(body), nicehash.RateLimitPrivate, withdrawal)
+	if err != nil {
+		// the money may have left when the outcome is unknown,
+		// only an explicit rejection frees the reservation
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode < 500 {
+			client.withdrawals.release(currency, amount, day)
+		}
+		return "", err
+	}
+	return withdrawal.Id, nil
+}
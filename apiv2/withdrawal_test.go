@@ -0,0 +1,191 @@
+package apiv2
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+	"github.com/stretchr/testify/assert"
+)
+
+func testWithdrawalServer(t *testing.T) (*Client, *int, func()) {
+	client, mux, server := testServer(t)
+
+	calls := 0
+	mux.HandleFunc("/main/api/v2/accounting/withdrawal", func(w http.ResponseWriter, r *http.Request) {
+		assertSigned(t, r)
+		assert.Equal(t, "POST", r.Method)
+		calls++
+		var body map[string]string
+		assert.Nil(t, json.NewDecoder(r.Body).Decode(&body))
+		w.Header().Set("Content-Type", "application/json")
+		if body["amount"] == "0.3" {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"error_id":"ERR","errors":[{"code":5054,"message":"Insufficient balance"}]}`)
+			return
+		}
+		assert.Equal(t, "BTC", body["currency"])
+		assert.Equal(t, "COLD", body["withdrawalAddressId"])
+		fmt.Fprint(w, `{"id":"W1"}`)
+	})
+
+	WithWithdrawalPolicy(WithdrawalPolicy{
+		AllowedAddressIds: []string{"COLD"},
+		DailyLimits:       map[string]Decimal{"BTC": "0.5"},
+		ConfirmationToken: "CONFIRM",
+	})(client)
+	return client, &calls, server.Close
+}
+
+func TestWithdraw(t *testing.T) {
+	client, calls, closer := testWithdrawalServer(t)
+	defer closer()
+
+	id, err := client.Withdraw(context.Background(), "BTC", "0.2", "COLD", "CONFIRM")
+	assert.Nil(t, err)
+	assert.Equal(t, "W1", id)
+
+	_, err = client.Withdraw(context.Background(), "BTC", "0.3", "COLD", "CONFIRM")
+	assert.IsType(t, &APIError{}, err)
+
+	_, err = client.Withdraw(context.Background(), "BTC", "0.2", "COLD", "CONFIRM")
+	assert.Nil(t, err)
+	assert.Equal(t, 3, *calls)
+}
+
+func TestWithdrawRefused(t *testing.T) {
+	client, calls, closer := testWithdrawalServer(t)
+	defer closer()
+
+	refused := []struct {
+		currency  string
+		amount    Decimal
+		addressId string
+		token     string
+	}{
+		{"BTC", "0.1", "COLD", "WRONG"},
+		{"BTC", "0.1", "HOT", "CONFIRM"},
+		{"ETH", "0.1", "COLD", "CONFIRM"},
+		{"BTC", "-0.1", "COLD", "CONFIRM"},
+		{"BTC", "abc", "COLD", "CONFIRM"},
+		{"BTC", "0.50000001", "COLD", "CONFIRM"},
+		{"BTC", "0x1p-1", "COLD", "CONFIRM"},
+		{"BTC", "1e-1", "COLD", "CONFIRM"},
+		{"BTC", "0_1", "COLD", "CONFIRM"},
+	}
+	for _, w := range refused {
+		_, err := client.Withdraw(context.Background(), w.currency, w.amount, w.addressId, w.token)
+		assert.True(t, errors.Is(err, ErrWithdrawalRefused), "%v", w)
+	}
+	assert.Equal(t, 0, *calls)
+}
+
+func TestWithdrawDailyLimit(t *testing.T) {
+	client, calls, closer := testWithdrawalServer(t)
+	defer closer()
+
+	now := time.Date(2019, 2, 1, 23, 0, 0, 0, time.UTC)
+	client.withdrawals.now = func() time.Time { return now }
+
+	_, err := client.Withdraw(context.Background(), "BTC", "0.25", "COLD", "CONFIRM")
+	assert.Nil(t, err)
+	_, err = client.Withdraw(context.Background(), "BTC", "0.25", "COLD", "CONFIRM")
+	assert.Nil(t, err)
+	_, err = client.Withdraw(context.Background(), "BTC", "0.00000001", "COLD", "CONFIRM")
+	assert.True(t, errors.Is(err, ErrWithdrawalRefused))
+	assert.Equal(t, 2, *calls)
+
+	now = now.Add(2 * time.Hour)
+	_, err = client.Withdraw(context.Background(), "BTC", "0.5", "COLD", "CONFIRM")
+	assert.Nil(t, err)
+	assert.Equal(t, 3, *calls)
+}
+
+func TestWithdrawReleaseAfterMidnight(t *testing.T) {
+	client, _, closer := testWithdrawalServer(t)
+	defer closer()
+
+	guard := client.withdrawals
+	now := time.Date(2019, 2, 1, 23, 59, 0, 0, time.UTC)
+	guard.now = func() time.Time { return now }
+
+	day, err := guard.reserve("BTC", "0.3", "COLD", "CONFIRM")
+	assert.Nil(t, err)
+	now = now.Add(2 * time.Minute)
+	_, err = guard.reserve("BTC", "0.4", "COLD", "CONFIRM")
+	assert.Nil(t, err)
+	// the rejection of the withdrawal of yesterday arrives today
+	guard.release("BTC", "0.3", day)
+
+	_, err = guard.reserve("BTC", "0.2", "COLD", "CONFIRM")
+	assert.True(t, errors.Is(err, ErrWithdrawalRefused))
+	_, err = guard.reserve("BTC", "0.1", "COLD", "CONFIRM")
+	assert.Nil(t, err)
+}
+
+func TestWithdrawPolicyCopied(t *testing.T) {
+	client, mux, server := testServer(t)
+	defer server.Close()
+
+	mux.HandleFunc("/main/api/v2/accounting/withdrawal", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("unexpected request")
+	})
+
+	policy := WithdrawalPolicy{
+		AllowedAddressIds: []string{"COLD"},
+		DailyLimits:       map[string]Decimal{"BTC": "0.5"},
+		ConfirmationToken: "CONFIRM",
+	}
+	assert.Nil(t, WithWithdrawalPolicy(policy)(client))
+	policy.AllowedAddressIds[0] = "HOT"
+	policy.DailyLimits["BTC"] = "100"
+	policy.DailyLimits["ETH"] = "100"
+
+	for _, w := range []struct {
+		currency  string
+		amount    Decimal
+		addressId string
+	}{
+		{"BTC", "0.1", "HOT"},
+		{"BTC", "1", "COLD"},
+		{"ETH", "0.1", "COLD"},
+	} {
+		_, err := client.Withdraw(context.Background(), w.currency, w.amount, w.addressId, "CONFIRM")
+		assert.True(t, errors.Is(err, ErrWithdrawalRefused), "%v", w)
+	}
+}
+
+func TestWithdrawWithoutPolicy(t *testing.T) {
+	client, mux, server := testServer(t)
+	defer server.Close()
+
+	mux.HandleFunc("/main/api/v2/accounting/withdrawal", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("unexpected request")
+	})
+
+	_, err := client.Withdraw(context.Background(), "BTC", "0.1", "COLD", "")
+	assert.True(t, errors.Is(err, ErrWithdrawalRefused))
+
+	_, err = New(WithWithdrawalPolicy(WithdrawalPolicy{AllowedAddressIds: []string{"COLD"}}))
+	assert.NotNil(t, err)
+}
+
+func TestWithdrawalAddresses(t *testing.T) {
+	client, mux, server := testServer(t)
+	defer server.Close()
+
+	mux.HandleFunc("/main/api/v2/accounting/withdrawalAddresses", func(w http.ResponseWriter, r *http.Request) {
+		assertSigned(t, r)
+		assert.Equal(t, "BTC", r.URL.Query().Get("currency"))
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"list":[{"id":"COLD","type":{"code":"BITCOIN"},"name":"cold storage","address":"bc1fake","currency":"BTC","status":{"code":"ACTIVE"}}]}`)
+	})
+
+	addresses, err := client.WithdrawalAddresses(context.Background(), "BTC")
+
+	assert.Nil(t, err)
+	assert.Equal(t, []WithdrawalAddress{{Id: "COLD", Type: "BITCOIN", Name: "cold storage", Address: "bc1fake", Currency: "BTC", Status: "ACTIVE"}}, addresses)
+}
@@ -1,20 +1,31 @@
 package nicehash
 
+import "context"
+
 type Balance struct {
-	Confirmed float64 `json:"balance_confirmed,string"`
-	Pending   float64 `json:"balance_pending,string"`
+	Confirmed Amount `json:"balance_confirmed"`
+	Pending   Amount `json:"balance_pending"`
 }
 
 func (client *NicehashClient) GetBalance() (Balance, error) {
+	return client.GetBalanceContext(context.Background())
+}
+
+func (client *NicehashClient) GetBalanceContext(ctx context.Context) (Balance, error) {
 	version := &struct {
-		Result Balance `json:"result"`
+		Result struct {
+			Balance
+			Error string `json:"error"`
+		} `json:"result"`
 	}{}
-	params := &Params{Method:"balance", Algo:AlgoTypeMAX, Location:LocationMAX, ApiId:client.apiid, ApiKey:client.apikey}
-	_, err := client.sling.New().Get("").QueryStruct(params).ReceiveSuccess(&version)
+	params := &Params{Method: "balance", Algo: AlgoTypeNone, Location: LocationMAX, ApiId: client.apiid, ApiKey: client.apikey}
+	resp, err := client.receive(ctx, client.sling.New().Get("").QueryStruct(params), &version)
 	if err != nil {
-		return version.Result, err
+		return version.Result.Balance, err
+	}
+	if err = client.checkResult(params.Method, resp, version.Result.Error); err != nil {
+		return version.Result.Balance, err
 	}
 
-	return version.Result, nil
+	return version.Result.Balance, nil
 }
-
@@ -14,8 +14,8 @@ func TestGetBalance(t *testing.T) {
 	sampleItem := `{"result":{"balance_confirmed":"0.00500000","balance_pending":"0.00000000"},"method":"balance"}`
 
 	expectedItem := Balance{
-		Confirmed: 0.005,
-		Pending: 0.00,
+		Confirmed: 500000,
+		Pending: 0,
 	}
 
 	mux.HandleFunc("/api", func(w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,97 @@
+package nicehash
+
+import (
+	"context"
+	"strings"
+)
+
+type BuyInfoAlgo struct {
+	Algo AlgoType `json:"algo"`
+	Name string   `json:"name"`
+	// SpeedText is the unit of the speeds and prices, eg. "TH" or "MSol".
+	SpeedText      string     `json:"speed_text"`
+	Multi          float64    `json:"multi,string"`
+	MinLimit       SpeedLimit `json:"min_limit"`
+	MaxLimit       SpeedLimit `json:"max_limit"`
+	MinDiffWorking float64    `json:"min_diff_working,string"`
+	MinDiffInitial float64    `json:"min_diff_initial,string"`
+	// DownStep is the (negative) price change of a price decrease, allowed
+	// once every DownTime seconds.
+	DownStep Price `json:"down_step"`
+	DownTime uint  `json:"down_time"`
+}
+
+type BuyInfo struct {
+	Algorithms []BuyInfoAlgo `json:"algorithms"`
+	MinAmount  Amount        `json:"min_amount"`
+	StaticFee  Amount        `json:"static_fee"`
+	DynamicFee float64       `json:"dynamic_fee,string"`
+}
+
+// Unit returns the unit of SpeedText and its name, eg. TH and "TH/s". A
+// bare prefix is a unit of hashes, eg. "T" is TH and "TH/s".
+func (a BuyInfoAlgo) Unit() (HashUnit, string) {
+	text := a.SpeedText
+	for _, p := range hashUnitPrefixes {
+		if p.prefix == "" || text == "" || !strings.EqualFold(text[:1], p.prefix) {
+			continue
+		}
+		if len(text) == 1 {
+			return p.unit, p.prefix + "H/s"
+		}
+		return p.unit, p.prefix + text[1:] + "/s"
+	}
+	if text == "" {
+		text = "H"
+	}
+	return H, text + "/s"
+}
+
+// Info returns the registry metadata of the algorithm. buy.info does not
+// send the precision of the prices, PriceStep is left zero, unknown.
+func (a BuyInfoAlgo) Info() AlgoInfo {
+	unit, unitName := a.Unit()
+	return AlgoInfo{
+		Algo:           a.Algo,
+		Name:           a.Name,
+		Unit:           unit,
+		UnitName:       unitName,
+		MinLimit:       a.MinLimit,
+		DecreaseAmount: -a.DownStep,
+	}
+}
+
+// Register adds or updates the algorithms of the buy info in the algorithm
+// registry, see RegisterAlgo. Every algorithm is tried, the first error is
+// returned.
+func (b BuyInfo) Register() error {
+	var first error
+	for _, algo := range b.Algorithms {
+		if err := RegisterAlgo(algo.Info()); err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
+
+func (client *NicehashClient) GetBuyInfo() (BuyInfo, error) {
+	return client.GetBuyInfoContext(context.Background())
+}
+
+func (client *NicehashClient) GetBuyInfoContext(ctx context.Context) (BuyInfo, error) {
+	stats := &struct {
+		Result struct {
+			BuyInfo
+			Error string `json:"error"`
+		} `json:"result"`
+	}{}
+	params := &Params{Method: "buy.info", Algo: AlgoTypeNone, Location: LocationMAX}
+	resp, err := client.receive(ctx, client.sling.New().Get("").QueryStruct(params), &stats)
+	if err != nil {
+		return BuyInfo{}, err
+	}
+	if err = client.checkResult(params.Method, resp, stats.Result.Error); err != nil {
+		return BuyInfo{}, err
+	}
+	return stats.Result.BuyInfo, nil
+}
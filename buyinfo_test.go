@@ -0,0 +1,139 @@
+package nicehash
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetBuyInfo(t *testing.T) {
+	restoreAlgos(t)
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	sampleItem := `{
+	   "result":{
+	      "algorithms":[
+		 {
+		    "down_time":60,
+		    "down_step":"-0.0001",
+		    "min_diff_working":"0.1",
+		    "min_limit":"0.01",
+		    "max_limit":"1000.00",
+		    "speed_text":"TH",
+		    "min_diff_initial":"2",
+		    "name":"SHA256",
+		    "algo":1,
+		    "multi":"1"
+		 },
+		 {
+		    "down_time":60,
+		    "down_step":"-0.0010",
+		    "min_diff_working":"0.001",
+		    "min_limit":"0.1",
+		    "max_limit":"5000.00",
+		    "speed_text":"GH",
+		    "min_diff_initial":"0.01",
+		    "name":"X16R",
+		    "algo":33,
+		    "multi":"1"
+		 },
+		 {
+		    "down_time":60,
+		    "down_step":"-0.0001",
+		    "min_diff_working":"0.01",
+		    "min_limit":"0.01",
+		    "max_limit":"1000.00",
+		    "speed_text":"GH",
+		    "min_diff_initial":"0.1",
+		    "name":"Skunk",
+		    "algo":29,
+		    "multi":"1"
+		 }
+	      ],
+	      "down_step":"-0.0001",
+	      "static_fee":"0.0001",
+	      "min_amount":"0.005",
+	      "dynamic_fee":"0.03"
+	   },
+	   "method":"buy.info"
+	}`
+
+	expectedItem := BuyInfo{
+		Algorithms: []BuyInfoAlgo{
+			{
+				Algo: AlgoTypeSHA256,
+				Name: "SHA256",
+				SpeedText: "TH",
+				Multi: 1,
+				MinLimit: 1000000,
+				MaxLimit: 100000000000,
+				MinDiffWorking: 0.1,
+				MinDiffInitial: 2,
+				DownStep: -10000,
+				DownTime: 60,
+			},
+			{
+				Algo: 33,
+				Name: "X16R",
+				SpeedText: "GH",
+				Multi: 1,
+				MinLimit: 10000000,
+				MaxLimit: 500000000000,
+				MinDiffWorking: 0.001,
+				MinDiffInitial: 0.01,
+				DownStep: -100000,
+				DownTime: 60,
+			},
+			{
+				Algo: AlgoTypeMAX,
+				Name: "Skunk",
+				SpeedText: "GH",
+				Multi: 1,
+				MinLimit: 1000000,
+				MaxLimit: 100000000000,
+				MinDiffWorking: 0.01,
+				MinDiffInitial: 0.1,
+				DownStep: -10000,
+				DownTime: 60,
+			},
+		},
+		MinAmount: 500000,
+		StaticFee: 10000,
+		DynamicFee: 0.03,
+	}
+
+	mux.HandleFunc("/api", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "buy.info", r.URL.Query().Get("method"))
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, sampleItem)
+	})
+
+	nicehashClient := NewNicehashClient(httpClient, "", "", "", "")
+	info, err := nicehashClient.GetBuyInfo()
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedItem, info)
+
+	assert.Nil(t, info.Register())
+	algo, err := ParseAlgo("x16r")
+	assert.Nil(t, err)
+	assert.Equal(t, AlgoType(33), algo)
+	assert.Equal(t, "X16R", algo.ToString())
+	registered, _ := LookupAlgo(algo)
+	assert.Equal(t, AlgoInfo{Algo: 33, Name: "X16R", Unit: GH, UnitName: "GH/s", MinLimit: 10000000, DecreaseAmount: 100000}, registered)
+	assert.Equal(t, "Skunk", AlgoTypeMAX.ToString())
+}
+
+func TestBuyInfoUnit(t *testing.T) {
+	for text, expected := range map[string]string{"TH": "TH/s", "MSol": "MSol/s", "Sol": "Sol/s", "kH": "kH/s", "H": "H/s", "": "H/s", "T": "TH/s", "k": "kH/s"} {
+		_, name := BuyInfoAlgo{SpeedText: text}.Unit()
+		assert.Equal(t, expected, name, text)
+	}
+	unit, _ := BuyInfoAlgo{SpeedText: "MSol"}.Unit()
+	assert.Equal(t, MH, unit)
+	unit, _ = BuyInfoAlgo{SpeedText: "T"}.Unit()
+	assert.Equal(t, TH, unit)
+}
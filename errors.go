@@ -0,0 +1,118 @@
+package nicehash
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// APIError is returned when the api answers with an error message or with
+// a non 2xx http status.
+type APIError struct {
+	Method     string // api method, eg. "orders.create"
+	Message    string // error message sent by the server, or the http status
+	StatusCode int    // http status code of the response
+}
+
+func (e *APIError) Error() string {
+	if e.Method == "" {
+		return "nicehash: " + e.Message
+	}
+	return fmt.Sprintf("nicehash: %s: %s", e.Method, e.Message)
+}
+
+// DecodeError is returned when a response does not have the expected
+// shape, eg. a number where a string was expected or a short array.
+type DecodeError struct {
+	Path     string // path of the value, eg. "ProviderWorker[4]"
+	Expected string // expected json type, eg. "string"
+	Actual   string // the json value found, possibly shortened
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("nicehash: decode %s: expected %s, got %s", e.Path, e.Expected, e.Actual)
+}
+
+// checkResult returns an *APIError when resp has a non 2xx status or the
+// result carried an error message. Throttling errors pause the rate limiter.
+func (client *NicehashClient) checkResult(method string, resp *http.Response, message string) error {
+	var err *APIError
+	if code := resp.StatusCode; code < 200 || 299 < code {
+		if message == "" {
+			message = "Http response: " + resp.Status
+		}
+		err = &APIError{Method: method, Message: message, StatusCode: code}
+	} else if message != "" {
+		err = &APIError{Method: method, Message: message, StatusCode: resp.StatusCode}
+	} else {
+		return nil
+	}
+	if limiter := client.httpClient.limiter; limiter != nil && IsRateLimited(err) && resp.Request != nil {
+		limiter.Backoff(rateLimitClass(resp.Request), retryAfter(resp))
+	}
+	return err
+}
+
+func asAPIError(err error) (*APIError, bool) {
+	var apiErr *APIError
+	ok := errors.As(err, &apiErr)
+	return apiErr, ok
+}
+
+func (e *APIError) contains(substrs ...string) bool {
+	message := strings.ToLower(e.Message)
+	for _, substr := range substrs {
+		if strings.Contains(message, substr) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsAuthError reports whether err was caused by a missing or wrong api id or key.
+func IsAuthError(err error) bool {
+	apiErr, ok := asAPIError(err)
+	if !ok {
+		return false
+	}
+	if apiErr.StatusCode == http.StatusUnauthorized || apiErr.StatusCode == http.StatusForbidden {
+		return true
+	}
+	return apiErr.contains("incorrect key", "incorrect id", "invalid key", "invalid id", "api id", "api key", "not authorized", "unauthorized")
+}
+
+// IsInsufficientBalance reports whether err was caused by a balance too low
+// to create or refill an order.
+func IsInsufficientBalance(err error) bool {
+	apiErr, ok := asAPIError(err)
+	return ok && apiErr.contains("not enough balance", "insufficient balance", "insufficient funds", "balance too low")
+}
+
+// IsPriceTooLow reports whether err was caused by an order price below the
+// allowed minimum.
+func IsPriceTooLow(err error) bool {
+	apiErr, ok := asAPIError(err)
+	return ok && apiErr.contains("price too low", "price is too low", "price below", "minimal price", "minimum price")
+}
+
+// IsRateLimited reports whether err was caused by sending too many requests.
+// The call can be retried later.
+func IsRateLimited(err error) bool {
+	apiErr, ok := asAPIError(err)
+	if !ok {
+		return false
+	}
+	if apiErr.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	return apiErr.contains("too many requests", "rate limit", "request limit", "too frequent")
+}
+
+// IsDecreaseCooldown reports whether err was caused by decreasing the price
+// of an order again before the decrease interval elapsed.
+// The call can be retried later.
+func IsDecreaseCooldown(err error) bool {
+	apiErr, ok := asAPIError(err)
+	return ok && apiErr.contains("decrease") && apiErr.contains("once", "wait", "minute", "cooldown", "too soon")
+}
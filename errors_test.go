@@ -0,0 +1,57 @@
+package nicehash
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIError(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	sampleItem := `{"result":{"error":"Incorrect key."},"method":"balance"}`
+
+	mux.HandleFunc("/api", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, sampleItem)
+	})
+
+	nicehashClient := NewNicehashClient(httpClient, "", "FAKEID", "FAKEKEY", "")
+	_, err := nicehashClient.GetBalance()
+
+	assert.Equal(t, &APIError{Method: "balance", Message: "Incorrect key.", StatusCode: 200}, err)
+	assert.True(t, IsAuthError(err))
+	assert.False(t, IsRateLimited(err))
+}
+
+func TestAPIErrorHttpStatus(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	mux.HandleFunc("/api", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	nicehashClient := NewNicehashClient(httpClient, "", "FAKEID", "FAKEKEY", "")
+	_, err := nicehashClient.OrderRemove(0, 0, 123)
+
+	assert.IsType(t, &APIError{}, err)
+	assert.Equal(t, http.StatusTooManyRequests, err.(*APIError).StatusCode)
+	assert.True(t, IsRateLimited(err))
+}
+
+func TestAPIErrorClassification(t *testing.T) {
+	apiError := func(message string) error {
+		return fmt.Errorf("wrapped: %w", &APIError{Method: "orders.create", Message: message, StatusCode: 200})
+	}
+
+	assert.True(t, IsAuthError(apiError("Incorrect ID or key.")))
+	assert.True(t, IsInsufficientBalance(apiError("Not enough balance to create order.")))
+	assert.True(t, IsPriceTooLow(apiError("Price too low.")))
+	assert.True(t, IsRateLimited(apiError("Too many requests, slow down.")))
+	assert.True(t, IsDecreaseCooldown(apiError("You can decrease price only once every 10 minutes.")))
+	assert.False(t, IsDecreaseCooldown(apiError("Price too low.")))
+	assert.False(t, IsAuthError(fmt.Errorf("Incorrect key.")))
+}
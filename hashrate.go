@@ -0,0 +1,167 @@
+package nicehash
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// HashUnit is a multiple of hashes (or solutions) per second.
+type HashUnit float64
+
+const (
+	H  HashUnit = 1
+	KH HashUnit = 1e3
+	MH HashUnit = 1e6
+	GH HashUnit = 1e9
+	TH HashUnit = 1e12
+	PH HashUnit = 1e15
+	EH HashUnit = 1e18
+)
+
+var hashUnitPrefixes = []struct {
+	unit   HashUnit
+	prefix string
+}{{EH, "E"}, {PH, "P"}, {TH, "T"}, {GH, "G"}, {MH, "M"}, {KH, "k"}, {H, ""}}
+
+func (u HashUnit) prefix() string {
+	for _, p := range hashUnitPrefixes {
+		if p.unit == u {
+			return p.prefix
+		}
+	}
+	return strconv.FormatFloat(float64(u), 'g', -1, 64) + " "
+}
+
+// Unit returns the unit of the speeds and prices of the algorithm, eg. TH
+// for SHA256. The speed limits, the accepted speeds and the prices of the
+// api are all in this unit.
+func (t AlgoType) Unit() HashUnit {
+	if info, ok := LookupAlgo(t); ok {
+		return info.Unit
+	}
+	return H
+}
+
+// UnitName returns the name of the unit of the algorithm, eg. "TH/s".
+func (t AlgoType) UnitName() string {
+	if info, ok := LookupAlgo(t); ok {
+		return info.UnitName
+	}
+	return "H/s"
+}
+
+// unitBase is the thing counted by the algorithm, hashes or solutions.
+func (t AlgoType) unitBase() string {
+	if strings.Contains(t.UnitName(), "Sol") {
+		return "Sol/s"
+	}
+	return "H/s"
+}
+
+// Hashrate is a speed of an algorithm.
+type Hashrate struct {
+	Algo AlgoType
+	// PerSecond is the speed in hashes (or solutions) per second.
+	PerSecond float64
+}
+
+// NewHashrate returns the speed value of algo in the unit of algo, eg.
+// NewHashrate(AlgoTypeSHA256, 1.25) is 1.25 TH/s.
+func NewHashrate(algo AlgoType, value float64) Hashrate {
+	return Hashrate{Algo: algo, PerSecond: value * float64(algo.Unit())}
+}
+
+// Value returns the speed in the unit of the algorithm.
+func (h Hashrate) Value() float64 {
+	return h.PerSecond / float64(h.Algo.Unit())
+}
+
+// In returns the speed in unit, eg. h.In(GH) for GH/s.
+func (h Hashrate) In(unit HashUnit) float64 {
+	return h.PerSecond / float64(unit)
+}
+
+// String formats the speed with the largest unit below it, eg. "1.25 TH/s".
+func (h Hashrate) String() string {
+	unit := H
+	for _, p := range hashUnitPrefixes {
+		if h.PerSecond >= float64(p.unit) || -h.PerSecond >= float64(p.unit) {
+			unit = p.unit
+			break
+		}
+	}
+	return strconv.FormatFloat(h.In(unit), 'f', -1, 64) + " " + unit.prefix() + h.Algo.unitBase()
+}
+
+// CostPerDay returns the cost of buying speed for a day at price, the
+// price of a unit of the algorithm of speed per day.
+func CostPerDay(price Price, speed Hashrate) Amount {
+	return price.MulFloat(speed.Value())
+}
+
+// Runtime returns how long amount buys speed at price.
+func Runtime(amount Amount, price Price, speed Hashrate) time.Duration {
+	cost := CostPerDay(price, speed)
+	if cost <= 0 {
+		return 0
+	}
+	return time.Duration(float64(amount) / float64(cost) * float64(24*time.Hour))
+}
+
+// Hashrate returns the total speed of the algorithm.
+func (s GlobalStats) Hashrate() Hashrate {
+	return NewHashrate(s.Algo, s.Speed)
+}
+
+// CostPerDay returns the cost of speed for a day at the average price.
+func (s GlobalStats) CostPerDay(speed Hashrate) Amount {
+	return CostPerDay(s.Price, speed)
+}
+
+// Limit returns the speed limit of the order, zero means no limit.
+func (o Orders) Limit() Hashrate {
+	return NewHashrate(o.Algo, o.LimitSpeed.Float64())
+}
+
+func (o Orders) Accepted() Hashrate {
+	return NewHashrate(o.Algo, o.AcceptedSpeed)
+}
+
+// Limit returns the speed limit of the order, zero means no limit.
+func (o MyOrders) Limit() Hashrate {
+	return NewHashrate(o.Algo, o.LimitSpeed.Float64())
+}
+
+func (o MyOrders) Accepted() Hashrate {
+	return NewHashrate(o.Algo, o.AcceptedSpeed)
+}
+
+// CostPerDay returns the cost of the accepted speed of the order for a day.
+func (o MyOrders) CostPerDay() Amount {
+	return CostPerDay(o.Price, o.Accepted())
+}
+
+func (s ProviderStats) Accepted() Hashrate {
+	return NewHashrate(s.Algo, s.AcceptedSpeed)
+}
+
+func (s ProviderStats) Rejected() Hashrate {
+	return NewHashrate(s.Algo, s.RejectedSpeed)
+}
+
+func (s ProviderExStats) Accepted() Hashrate {
+	return NewHashrate(s.Algo, s.AcceptedSpeed)
+}
+
+func (s ProviderExStats) Rejected() Hashrate {
+	return NewHashrate(s.Algo, s.RejectedSpeed)
+}
+
+func (w ProviderWorker) Accepted() Hashrate {
+	return NewHashrate(w.Algo, w.AcceptedSpeed)
+}
+
+func (w ProviderWorker) Rejected() Hashrate {
+	return NewHashrate(w.Algo, w.RejectedSpeed)
+}
@@ -0,0 +1,44 @@
+package nicehash
+
+import (
+	"testing"
+	"time"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAlgoUnit(t *testing.T) {
+	assert.Equal(t, TH, AlgoTypeSHA256.Unit())
+	assert.Equal(t, "TH/s", AlgoTypeSHA256.UnitName())
+	assert.Equal(t, "GH/s", AlgoTypeScrypt.UnitName())
+	assert.Equal(t, "MH/s", AlgoTypeDaggerHashimoto.UnitName())
+	assert.Equal(t, "Sol/s", AlgoTypeEquihash.UnitName())
+}
+
+func TestHashrate(t *testing.T) {
+	speed := NewHashrate(AlgoTypeSHA256, 1.25)
+	assert.Equal(t, 1.25e12, speed.PerSecond)
+	assert.Equal(t, 1.25, speed.Value())
+	assert.Equal(t, 1250.0, speed.In(GH))
+	assert.Equal(t, "1.25 TH/s", speed.String())
+
+	assert.Equal(t, "350 MH/s", NewHashrate(AlgoTypeDaggerHashimoto, 350).String())
+	assert.Equal(t, "1.5 GH/s", NewHashrate(AlgoTypeDaggerHashimoto, 1500).String())
+	assert.Equal(t, "2 kSol/s", NewHashrate(AlgoTypeEquihash, 2000).String())
+	assert.Equal(t, "0 H/s", Hashrate{Algo: AlgoTypeScrypt}.String())
+}
+
+func TestCostPerDay(t *testing.T) {
+	price := Price(5050000) // 0.0505 BTC/TH/day
+	speed := NewHashrate(AlgoTypeSHA256, 2)
+	assert.Equal(t, Amount(10100000), CostPerDay(price, speed))
+	assert.Equal(t, 12*time.Hour, Runtime(5050000, price, speed))
+	assert.Equal(t, time.Duration(0), Runtime(5050000, 0, speed))
+
+	stats := GlobalStats{Algo: AlgoTypeSHA256, Price: price, Speed: 1000}
+	assert.Equal(t, "1 PH/s", stats.Hashrate().String())
+	assert.Equal(t, Amount(10100000), stats.CostPerDay(speed))
+
+	order := MyOrders{Algo: AlgoTypeSHA256, Price: price, AcceptedSpeed: 0.5, LimitSpeed: 100000000}
+	assert.Equal(t, Amount(2525000), order.CostPerDay())
+	assert.Equal(t, "1 TH/s", order.Limit().String())
+}
@@ -0,0 +1,268 @@
+package nicehash
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// HistoryInterval is the interval of the points of the provider history.
+const HistoryInterval = 5 * time.Minute
+
+// HistorySeries is a provider history ordered by time. The slots without
+// accepted and rejected speed are kept, a slot missing from the series
+// means the server has no data for it.
+type HistorySeries []ProviderExHistoryItem
+
+// Interval is the time range [Start, End).
+type Interval struct {
+	Start time.Time
+	End   time.Time
+}
+
+func (i Interval) Duration() time.Duration {
+	return i.End.Sub(i.Start)
+}
+
+// Earning is the growth of the unpaid balance during an interval.
+type Earning struct {
+	Interval
+	Amount Amount
+}
+
+// sort orders the series by time and drops the repeated slots, the last
+// one wins.
+func (s *HistorySeries) sort() {
+	series := *s
+	sort.SliceStable(series, func(i, j int) bool { return series[i].Time.Before(series[j].Time) })
+	out := series[:0]
+	for _, item := range series {
+		if len(out) > 0 && out[len(out)-1].Time.Equal(item.Time) {
+			out[len(out)-1] = item
+			continue
+		}
+		out = append(out, item)
+	}
+	*s = out
+}
+
+// Gaps returns the intervals of at least min without data, the missing
+// slots between two points of the series.
+func (s HistorySeries) Gaps(min time.Duration) []Interval {
+	var gaps []Interval
+	for i := 1; i < len(s); i++ {
+		gap := Interval{Start: s[i-1].Time.Add(HistoryInterval), End: s[i].Time}
+		if gap.Duration() > 0 && gap.Duration() >= min {
+			gaps = append(gaps, gap)
+		}
+	}
+	return gaps
+}
+
+// Outages returns the intervals of at least min when the points of the
+// series have no accepted speed. The gaps without data are not outages.
+func (s HistorySeries) Outages(min time.Duration) []Interval {
+	var outages []Interval
+	var current *Interval
+	for i, item := range s {
+		connected := i > 0 && item.Time.Sub(s[i-1].Time) <= HistoryInterval
+		if item.AcceptedSpeed > 0 || !connected {
+			if current != nil && current.Duration() >= min {
+				outages = append(outages, *current)
+			}
+			current = nil
+		}
+		if item.AcceptedSpeed > 0 {
+			continue
+		}
+		if current == nil {
+			current = &Interval{Start: item.Time}
+		}
+		current.End = item.Time.Add(HistoryInterval)
+	}
+	if current != nil && current.Duration() >= min {
+		outages = append(outages, *current)
+	}
+	return outages
+}
+
+// Resample returns the series in buckets of bucket, eg. 15 minutes, an
+// hour or a day, aligned to UTC. The speeds of a bucket are the average of
+// its points, the unpaid balance the one of its last point. The buckets
+// without points are left out.
+func (s HistorySeries) Resample(bucket time.Duration) HistorySeries {
+	var out HistorySeries
+	count := 0
+	for _, item := range s {
+		start := item.Time.UTC().Truncate(bucket)
+		if len(out) == 0 || !out[len(out)-1].Time.Equal(start) {
+			if count > 0 {
+				out[len(out)-1].AcceptedSpeed /= float64(count)
+				out[len(out)-1].RejectedSpeed /= float64(count)
+			}
+			out = append(out, ProviderExHistoryItem{Time: start})
+			count = 0
+		}
+		last := &out[len(out)-1]
+		last.AcceptedSpeed += item.AcceptedSpeed
+		last.RejectedSpeed += item.RejectedSpeed
+		last.Unpaid = item.Unpaid
+		count++
+	}
+	if count > 0 {
+		out[len(out)-1].AcceptedSpeed /= float64(count)
+		out[len(out)-1].RejectedSpeed /= float64(count)
+	}
+	return out
+}
+
+// RollingAverage returns the series with the speeds of every point
+// averaged over the points of the preceding window, the point included.
+func (s HistorySeries) RollingAverage(window time.Duration) HistorySeries {
+	out := make(HistorySeries, len(s))
+	var accepted, rejected float64
+	first := 0
+	for i, item := range s {
+		accepted += item.AcceptedSpeed
+		rejected += item.RejectedSpeed
+		for first < i && !s[first].Time.After(item.Time.Add(-window)) {
+			accepted -= s[first].AcceptedSpeed
+			rejected -= s[first].RejectedSpeed
+			first++
+		}
+		count := float64(i - first + 1)
+		out[i] = item
+		out[i].AcceptedSpeed = accepted / count
+		out[i].RejectedSpeed = rejected / count
+	}
+	return out
+}
+
+// Earnings returns the growth of the unpaid balance between the points of
+// the series. A drop of the balance is a payout, the earning of that
+// interval is then the new balance.
+func (s HistorySeries) Earnings() []Earning {
+	var earnings []Earning
+	for i := 1; i < len(s); i++ {
+		amount := s[i].Unpaid.Sub(s[i-1].Unpaid)
+		if amount < 0 {
+			amount = s[i].Unpaid
+		}
+		earnings = append(earnings, Earning{Interval{s[i-1].Time, s[i].Time}, amount})
+	}
+	return earnings
+}
+
+// Earned returns the total of the earnings of the series.
+func (s HistorySeries) Earned() Amount {
+	var total Amount
+	for _, earning := range s.Earnings() {
+		total = total.Add(earning.Amount)
+	}
+	return total
+}
+
+// Merge returns the points of s and other in one series, the points of
+// other replace the ones of s on the same slots.
+func (s HistorySeries) Merge(other HistorySeries) HistorySeries {
+	out := make(HistorySeries, 0, len(s)+len(other))
+	out = append(append(out, s...), other...)
+	out.sort()
+	return out
+}
+
+// ProviderExHistoryIterator walks back the history of a provider address
+// window by window, and merges the history of the algorithms fetched so
+// far.
+//
+//	it := client.ProviderExHistory(addr, time.Now().AddDate(0, -3, 0), 24*time.Hour)
+//	for it.Next(ctx) {
+//	}
+//	if it.Err() != nil {
+//		...
+//	}
+//	history := it.History()
+type ProviderExHistoryIterator struct {
+	api     NicehashAPI
+	addr    string
+	since   time.Time
+	window  time.Duration
+	from    time.Time
+	stats   StatsProviderEx
+	history map[AlgoType]HistorySeries
+	err     error
+}
+
+// HistoryEpoch is the earliest since of ProviderExHistory, NiceHash has no
+// history before.
+var HistoryEpoch = time.Date(2014, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// ProviderExHistory returns an iterator over the history of addr from now
+// back to since, in steps of window. A since before HistoryEpoch, the zero
+// time included, or a window of zero or less is an error of the iterator.
+func (client *NicehashClient) ProviderExHistory(addr string, since time.Time, window time.Duration) *ProviderExHistoryIterator {
+	return NewProviderExHistoryIterator(client, addr, since, window)
+}
+
+// NewProviderExHistoryIterator returns the iterator of ProviderExHistory
+// over api, eg. a fake in tests.
+func NewProviderExHistoryIterator(api NicehashAPI, addr string, since time.Time, window time.Duration) *ProviderExHistoryIterator {
+	it := &ProviderExHistoryIterator{
+		api:     api,
+		addr:    addr,
+		since:   since,
+		window:  window,
+		from:    time.Now(),
+		history: map[AlgoType]HistorySeries{},
+	}
+	if since.Before(HistoryEpoch) {
+		it.err = fmt.Errorf("nicehash: history since %s is before %s", since.Format(time.RFC3339), HistoryEpoch.Format(time.RFC3339))
+	} else if window <= 0 {
+		it.err = fmt.Errorf("nicehash: invalid history window %s", window)
+	}
+	return it
+}
+
+// Next fetches the window before the previous one. It returns false when
+// since is reached or on error.
+func (it *ProviderExHistoryIterator) Next(ctx context.Context) bool {
+	if it.err != nil || !it.from.After(it.since) {
+		return false
+	}
+	from := it.from.Add(-it.window)
+	if from.Before(it.since) {
+		from = it.since
+	}
+	stats, err := it.api.GetStatsProviderExSinceContext(ctx, it.addr, from)
+	if err != nil {
+		it.err = err
+		return false
+	}
+	it.from = from
+	it.stats = stats
+	for _, past := range stats.Past {
+		it.history[past.Algo] = it.history[past.Algo].Merge(past.Data)
+	}
+	return true
+}
+
+// Stats returns the response of the last window.
+func (it *ProviderExHistoryIterator) Stats() StatsProviderEx {
+	return it.stats
+}
+
+// From returns the start of the last window.
+func (it *ProviderExHistoryIterator) From() time.Time {
+	return it.from
+}
+
+// History returns the merged history of the windows fetched so far by
+// algorithm.
+func (it *ProviderExHistoryIterator) History() map[AlgoType]HistorySeries {
+	return it.history
+}
+
+func (it *ProviderExHistoryIterator) Err() error {
+	return it.err
+}
@@ -0,0 +1,174 @@
+package nicehash
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+	"github.com/stretchr/testify/assert"
+)
+
+func testSeries(start time.Time, unpaid Amount, speeds ...float64) HistorySeries {
+	var series HistorySeries
+	for i, speed := range speeds {
+		if speed < 0 {
+			continue // no data
+		}
+		unpaid += 100
+		series = append(series, ProviderExHistoryItem{Time: start.Add(time.Duration(i) * HistoryInterval), AcceptedSpeed: speed, Unpaid: unpaid})
+	}
+	return series
+}
+
+func TestProviderExHistorySeries(t *testing.T) {
+	var history ProviderExHistory
+	err := json.Unmarshal([]byte(`{"algo":20,"data":[[5000002,{"a":"2"},"0.3"],[5000000,{"a":"1"},"0.1"],[5000001,{},"0.2"]]}`), &history)
+
+	assert.Nil(t, err)
+	start := time.Unix(5000000*300, 0).UTC()
+	assert.Equal(t, HistorySeries{
+		{Time: start, AcceptedSpeed: 1, Unpaid: 10000000},
+		{Time: start.Add(5 * time.Minute), Unpaid: 20000000},
+		{Time: start.Add(10 * time.Minute), AcceptedSpeed: 2, Unpaid: 30000000},
+	}, history.Data)
+}
+
+func TestHistoryGapsAndOutages(t *testing.T) {
+	start := time.Date(2019, 2, 1, 10, 0, 0, 0, time.UTC)
+	series := testSeries(start, 0, 1, 0, 0, 1, -1, -1, -1, 0, 1)
+
+	assert.Equal(t, []Interval{{start.Add(20 * time.Minute), start.Add(35 * time.Minute)}}, series.Gaps(5*time.Minute))
+	assert.Nil(t, series.Gaps(20*time.Minute))
+	assert.Equal(t, []Interval{
+		{start.Add(5 * time.Minute), start.Add(15 * time.Minute)},
+		{start.Add(35 * time.Minute), start.Add(40 * time.Minute)},
+	}, series.Outages(0))
+	assert.Equal(t, []Interval{{start.Add(5 * time.Minute), start.Add(15 * time.Minute)}}, series.Outages(10*time.Minute))
+}
+
+func TestHistoryResample(t *testing.T) {
+	start := time.Date(2019, 2, 1, 10, 5, 0, 0, time.UTC)
+	series := testSeries(start, 0, 1, 2, 3, 4, -1, 6)
+
+	assert.Equal(t, HistorySeries{
+		{Time: start.Add(-5 * time.Minute), AcceptedSpeed: 1.5, Unpaid: 200},
+		{Time: start.Add(10 * time.Minute), AcceptedSpeed: 3.5, Unpaid: 400},
+		{Time: start.Add(25 * time.Minute), AcceptedSpeed: 6, Unpaid: 500},
+	}, series.Resample(15*time.Minute))
+	assert.Len(t, series.Resample(24*time.Hour), 1)
+	assert.Equal(t, 3.2, series.Resample(time.Hour)[0].AcceptedSpeed)
+}
+
+func TestHistoryRollingAverage(t *testing.T) {
+	start := time.Date(2019, 2, 1, 10, 0, 0, 0, time.UTC)
+	series := testSeries(start, 0, 1, 2, 3, 4)
+
+	averaged := series.RollingAverage(10 * time.Minute)
+	assert.Equal(t, []float64{1, 1.5, 2.5, 3.5}, []float64{averaged[0].AcceptedSpeed, averaged[1].AcceptedSpeed, averaged[2].AcceptedSpeed, averaged[3].AcceptedSpeed})
+	assert.Equal(t, series[3].Unpaid, averaged[3].Unpaid)
+	assert.Equal(t, series, series.RollingAverage(0))
+}
+
+func TestHistoryEarnings(t *testing.T) {
+	start := time.Date(2019, 2, 1, 10, 0, 0, 0, time.UTC)
+	series := HistorySeries{
+		{Time: start, Unpaid: 1000},
+		{Time: start.Add(5 * time.Minute), Unpaid: 1500},
+		{Time: start.Add(10 * time.Minute), Unpaid: 200},
+		{Time: start.Add(15 * time.Minute), Unpaid: 200},
+	}
+
+	assert.Equal(t, []Earning{
+		{Interval{start, start.Add(5 * time.Minute)}, 500},
+		{Interval{start.Add(5 * time.Minute), start.Add(10 * time.Minute)}, 200},
+		{Interval{start.Add(10 * time.Minute), start.Add(15 * time.Minute)}, 0},
+	}, series.Earnings())
+	assert.Equal(t, Amount(700), series.Earned())
+}
+
+func TestHistoryMerge(t *testing.T) {
+	start := time.Date(2019, 2, 1, 10, 0, 0, 0, time.UTC)
+	older := testSeries(start, 0, 1, 2, 3)
+	newer := testSeries(start.Add(10*time.Minute), 1000, 5, 6)
+
+	merged := older.Merge(newer)
+	assert.Len(t, merged, 4)
+	assert.Equal(t, 5.0, merged[2].AcceptedSpeed)
+	assert.Equal(t, 3.0, older[2].AcceptedSpeed)
+}
+
+func TestGetStatsProviderExSince(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	mux.HandleFunc("/api", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "stats.provider.ex", r.URL.Query().Get("method"))
+		assert.Equal(t, "1500000000", r.URL.Query().Get("from"))
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"result":{"current":[],"past":[{"algo":20,"data":[[5000000,{"a":"1"},"0.1"]]}],"payments":[]},"method":"stats.provider.ex"}`)
+	})
+
+	nicehashClient := NewNicehashClient(httpClient, "", "FAKEID", "FAKEKEY", "")
+	stats, err := nicehashClient.GetStatsProviderExSince("FAKEADDR", time.Unix(1500000000, 0))
+
+	assert.Nil(t, err)
+	assert.Len(t, stats.Past, 1)
+	assert.Len(t, stats.Past[0].Data, 1)
+}
+
+func TestProviderExHistoryIterator(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	var requests []int64
+	mux.HandleFunc("/api", func(w http.ResponseWriter, r *http.Request) {
+		from, err := strconv.ParseInt(r.URL.Query().Get("from"), 10, 64)
+		assert.Nil(t, err)
+		requests = append(requests, from)
+		// two hours of history from the start, the last hour overlaps the next window
+		slot := from / 300
+		data := ""
+		for i := int64(0); i < 24; i++ {
+			if i > 0 {
+				data += ","
+			}
+			data += fmt.Sprintf(`[%d,{"a":"1"},"0.%08d"]`, slot+i, slot+i)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"result":{"current":[],"past":[{"algo":20,"data":[%s]}],"payments":[]},"method":"stats.provider.ex"}`, data)
+	})
+
+	nicehashClient := NewNicehashClient(httpClient, "", "FAKEID", "FAKEKEY", "")
+	since := time.Now().Add(-150 * time.Minute)
+	it := nicehashClient.ProviderExHistory("FAKEADDR", since, time.Hour)
+	windows := 0
+	for it.Next(context.Background()) {
+		windows++
+	}
+
+	assert.Nil(t, it.Err())
+	assert.Equal(t, 3, windows)
+	assert.Equal(t, since.Unix(), requests[2])
+	assert.Equal(t, since.Unix(), it.From().Unix())
+	history := it.History()[AlgoTypeDaggerHashimoto]
+	assert.Nil(t, history.Gaps(0))
+	assert.Equal(t, since.Unix()/300*300, history[0].Time.Unix())
+	assert.False(t, it.Next(context.Background()))
+}
+
+func TestProviderExHistoryIteratorInvalid(t *testing.T) {
+	nicehashClient, err := New(WithBaseURL("http://127.0.0.1:1/"))
+	assert.Nil(t, err)
+
+	for _, it := range []*ProviderExHistoryIterator{
+		nicehashClient.ProviderExHistory("FAKEADDR", time.Time{}, time.Hour),
+		nicehashClient.ProviderExHistory("FAKEADDR", time.Date(2013, 12, 31, 0, 0, 0, 0, time.UTC), time.Hour),
+		nicehashClient.ProviderExHistory("FAKEADDR", time.Now().Add(-time.Hour), 0),
+	} {
+		assert.False(t, it.Next(context.Background()))
+		assert.NotNil(t, it.Err())
+	}
+}
@@ -0,0 +1,57 @@
+package nicehash
+
+import (
+	"encoding/json"
+	"math"
+	"net/url"
+)
+
+// SpeedLimit is an exact speed limit of an order in the unit of its
+// algorithm, eg. TH/s for SHA256, with 8 decimals. It is encoded as a
+// decimal string, eg. "1.5", in json and in the query strings. Zero means
+// no limit.
+type SpeedLimit int64
+
+const speedLimitScale = 1e8
+
+// ParseSpeedLimit parses a decimal number with at most 8 significant
+// fractional digits, eg. "0.01" or "1000".
+func ParseSpeedLimit(s string) (SpeedLimit, error) {
+	value, err := parseFixed(s, "speed limit")
+	return SpeedLimit(value), err
+}
+
+// SpeedLimitFromFloat returns the speed limit nearest to f.
+func SpeedLimitFromFloat(f float64) SpeedLimit {
+	return SpeedLimit(math.Round(f * speedLimitScale))
+}
+
+// Float64 returns the speed limit in the unit of the algorithm, possibly
+// rounded.
+func (l SpeedLimit) Float64() float64 {
+	return float64(l) / speedLimitScale
+}
+
+func (l SpeedLimit) IsZero() bool {
+	return l == 0
+}
+
+// String returns the speed limit without trailing zeros, eg. "1.5".
+func (l SpeedLimit) String() string {
+	return formatFixed(int64(l))
+}
+
+func (l SpeedLimit) MarshalJSON() ([]byte, error) {
+	return json.Marshal(l.String())
+}
+
+// UnmarshalJSON accepts json strings and numbers, the empty string is zero.
+// Unlike ParseSpeedLimit it rounds the numbers with more than 8 decimals.
+func (l *SpeedLimit) UnmarshalJSON(data []byte) error {
+	return unmarshalFixed(data, "speed limit", (*int64)(l))
+}
+
+func (l SpeedLimit) EncodeValues(key string, v *url.Values) error {
+	v.Add(key, l.String())
+	return nil
+}
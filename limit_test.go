@@ -0,0 +1,40 @@
+package nicehash
+
+import (
+	"encoding/json"
+	"testing"
+	"github.com/google/go-querystring/query"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSpeedLimit(t *testing.T) {
+	limit, err := ParseSpeedLimit("1.5")
+	assert.Nil(t, err)
+	assert.Equal(t, SpeedLimit(150000000), limit)
+	assert.Equal(t, "1.5", limit.String())
+	assert.Equal(t, 1.5, limit.Float64())
+	assert.Equal(t, limit, SpeedLimitFromFloat(1.5))
+
+	for _, text := range []string{"", "abc", "0.000000001", "-+1"} {
+		_, err := ParseSpeedLimit(text)
+		assert.NotNil(t, err, text)
+	}
+}
+
+func TestSpeedLimitEncoding(t *testing.T) {
+	var order MyOrders
+	assert.Nil(t, json.Unmarshal([]byte(`{"limit_speed":"0.01"}`), &order))
+	assert.Equal(t, SpeedLimit(1000000), order.LimitSpeed)
+
+	data, err := json.Marshal(NewOrder{LimitSpeed: 1000000})
+	assert.Nil(t, err)
+	assert.Contains(t, string(data), `"limit":"0.01"`)
+
+	values, err := query.Values(&Params{Method: "orders.set.limit", Limit: 250000000})
+	assert.Nil(t, err)
+	assert.Equal(t, "2.5", values.Get("limit"))
+	values, err = query.Values(&Params{Method: "orders.set.limit"})
+	assert.Nil(t, err)
+	_, ok := values["limit"]
+	assert.False(t, ok)
+}
@@ -0,0 +1,108 @@
+package nicehash
+
+import (
+	"context"
+	"log"
+	"log/slog"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"regexp"
+	"time"
+)
+
+// Logger receives the log records of the client. *slog.Logger satisfies it.
+type Logger interface {
+	Log(ctx context.Context, level slog.Level, msg string, args ...any)
+}
+
+// DefaultRedactedParams are the query parameters hidden in the log records,
+// they carry the api credentials and the payout address.
+var DefaultRedactedParams = []string{"key", "id", "addr"}
+
+const redacted = "REDACTED"
+
+// SetLogger sets the logger of the client. A nil logger means slog.Default(),
+// or a debug level logger writing to the output of the log package when
+// debug is enabled, see SetDebug. Every request is logged at debug level
+// with the api method, the http status and the latency.
+func (client *NicehashClient) SetLogger(logger Logger) {
+	client.httpClient.logger = logger
+}
+
+// SetRedactedParams replaces the query parameters (and json fields of the
+// response dumps) hidden in the log records.
+func (client *NicehashClient) SetRedactedParams(params ...string) {
+	client.httpClient.redact = params
+}
+
+func (d nicehashHttpClient) log(ctx context.Context, level slog.Level, msg string, args ...any) {
+	logger := d.logger
+	if logger == nil && d.debug {
+		// slog.Default() drops debug records, the dumps of SetDebug go to
+		// the output of the log package like before the slog support
+		logger = slog.New(slog.NewTextHandler(log.Writer(), &slog.HandlerOptions{Level: slog.LevelDebug}))
+	} else if logger == nil {
+		logger = slog.Default()
+	}
+	logger.Log(ctx, level, msg, args...)
+}
+
+func (d nicehashHttpClient) logRequest(req *http.Request, resp *http.Response, err error, latency time.Duration) {
+	args := []any{
+		slog.String("method", req.URL.Query().Get("method")),
+		slog.String("url", d.redactURL(req.URL).String()),
+		slog.Duration("latency", latency),
+	}
+	if err != nil {
+		d.log(req.Context(), slog.LevelDebug, "nicehash request failed", append(args, slog.Any("error", err))...)
+		return
+	}
+	d.log(req.Context(), slog.LevelDebug, "nicehash request", append(args, slog.Int("status", resp.StatusCode))...)
+}
+
+func (d nicehashHttpClient) dumpRequest(r *http.Request) {
+	redactedReq := r.Clone(r.Context())
+	redactedReq.URL = d.redactURL(r.URL)
+	dump, err := httputil.DumpRequest(redactedReq, true)
+	if err != nil {
+		d.log(r.Context(), slog.LevelDebug, "nicehash dump request", slog.Any("error", err))
+	} else {
+		d.log(r.Context(), slog.LevelDebug, "nicehash dump request", slog.String("dump", string(dump)))
+	}
+}
+
+func (d nicehashHttpClient) dumpResponse(req *http.Request, r *http.Response) {
+	if r == nil {
+		d.log(req.Context(), slog.LevelDebug, "nicehash dump response", slog.String("dump", "<nil>"))
+		return
+	}
+	dump, err := httputil.DumpResponse(r, true)
+	if err != nil {
+		d.log(req.Context(), slog.LevelDebug, "nicehash dump response", slog.Any("error", err))
+	} else {
+		d.log(req.Context(), slog.LevelDebug, "nicehash dump response", slog.String("dump", d.redactJSON(string(dump))))
+	}
+}
+
+// redactURL returns a copy of u with the redacted query parameters masked.
+func (d nicehashHttpClient) redactURL(u *url.URL) *url.URL {
+	query := u.Query()
+	for _, param := range d.redact {
+		if query.Has(param) {
+			query.Set(param, redacted)
+		}
+	}
+	masked := *u
+	masked.RawQuery = query.Encode()
+	return &masked
+}
+
+// redactJSON masks the string values of the redacted fields in a json text.
+func (d nicehashHttpClient) redactJSON(text string) string {
+	for _, param := range d.redact {
+		re := regexp.MustCompile(`("` + regexp.QuoteMeta(param) + `"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+		text = re.ReplaceAllString(text, `${1}"`+redacted+`"`)
+	}
+	return text
+}
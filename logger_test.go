@@ -0,0 +1,86 @@
+package nicehash
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"log/slog"
+	"net/http"
+	"testing"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoggerRedaction(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	mux.HandleFunc("/api", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"result":{"addr":"FAKEADDR","workers":[]},"method":"stats.provider.workers"}`)
+	})
+
+	var buf bytes.Buffer
+	nicehashClient := NewNicehashClient(httpClient, "", "FAKEID", "FAKEKEY", "")
+	nicehashClient.SetLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	nicehashClient.SetDebug(true)
+	_, err := nicehashClient.GetStatsProviderWorkers("FAKEADDR", 0)
+	assert.Nil(t, err)
+	_, err = nicehashClient.GetBalance()
+	assert.Nil(t, err)
+
+	logs := buf.String()
+	assert.Contains(t, logs, "method=stats.provider.workers")
+	assert.Contains(t, logs, "method=balance")
+	assert.Contains(t, logs, "status=200")
+	assert.Contains(t, logs, "latency=")
+	assert.Contains(t, logs, "REDACTED")
+	assert.NotContains(t, logs, "FAKEID")
+	assert.NotContains(t, logs, "FAKEKEY")
+	assert.NotContains(t, logs, "FAKEADDR")
+}
+
+func TestLoggerRedactsTransportErrors(t *testing.T) {
+	var buf bytes.Buffer
+	nicehashClient, err := New(WithBaseURL("http://127.0.0.1:1/"), WithCredentials("FAKEID", "FAKEKEY"))
+	assert.Nil(t, err)
+	nicehashClient.SetLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	_, err = nicehashClient.GetBalance()
+
+	assert.NotNil(t, err)
+	assert.NotContains(t, err.Error(), "FAKEKEY")
+	logs := buf.String()
+	assert.Contains(t, logs, "nicehash request failed")
+	assert.Contains(t, logs, "REDACTED")
+	assert.NotContains(t, logs, "FAKEID")
+	assert.NotContains(t, logs, "FAKEKEY")
+}
+
+func TestDebugWithoutLogger(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	mux.HandleFunc("/api", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"result":{"balance_confirmed":"0.005","balance_pending":"0"},"method":"balance"}`)
+	})
+
+	var buf bytes.Buffer
+	output := log.Writer()
+	log.SetOutput(&buf)
+	defer log.SetOutput(output)
+
+	nicehashClient := NewNicehashClient(httpClient, "", "FAKEID", "FAKEKEY", "")
+	_, err := nicehashClient.GetBalance()
+	assert.Nil(t, err)
+	assert.Empty(t, buf.String())
+
+	nicehashClient.SetDebug(true)
+	_, err = nicehashClient.GetBalance()
+	assert.Nil(t, err)
+
+	logs := buf.String()
+	assert.Contains(t, logs, "nicehash dump request")
+	assert.Contains(t, logs, "nicehash dump response")
+	assert.Contains(t, logs, "method=balance")
+	assert.NotContains(t, logs, "FAKEKEY")
+}
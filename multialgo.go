@@ -0,0 +1,87 @@
+package nicehash
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// MultiAlgo is the paying price and the stratum of an algorithm.
+type MultiAlgo struct {
+	Algo AlgoType `json:"algo"`
+	// Name is the stratum name of the algorithm, eg. "daggerhashimoto".
+	Name string `json:"name"`
+	// Paying is the current price paid to the miners per day.
+	Paying Price  `json:"paying"`
+	Port   uint16 `json:"port"`
+}
+
+// stratumRegions are the stratum subdomains of the locations.
+var stratumRegions = map[Location]string{
+	LocationNiceHash: "eu",
+	LocationWestHash: "usa",
+}
+
+// StratumHost returns the stratum server of the algorithm at location, eg.
+// "daggerhashimoto.eu.nicehash.com".
+func (m MultiAlgo) StratumHost(location Location) (string, error) {
+	region, ok := stratumRegions[location]
+	if !ok {
+		return "", fmt.Errorf("nicehash: no stratum at location %d", int(location))
+	}
+	return m.Name + "." + region + ".nicehash.com", nil
+}
+
+// StratumURL returns the url of the stratum server of the algorithm at
+// location, eg. "stratum+tcp://daggerhashimoto.eu.nicehash.com:3353".
+func (m MultiAlgo) StratumURL(location Location) (string, error) {
+	host, err := m.StratumHost(location)
+	if err != nil {
+		return "", err
+	}
+	return "stratum+tcp://" + host + ":" + strconv.Itoa(int(m.Port)), nil
+}
+
+func (client *NicehashClient) GetMultiAlgoInfo() ([]MultiAlgo, error) {
+	return client.GetMultiAlgoInfoContext(context.Background())
+}
+
+func (client *NicehashClient) GetMultiAlgoInfoContext(ctx context.Context) ([]MultiAlgo, error) {
+	stats := &struct {
+		Result struct {
+			Error     string      `json:"error"`
+			MultiAlgo []MultiAlgo `json:"multialgo"`
+		} `json:"result"`
+	}{}
+	params := &Params{Method: "multialgo.info", Algo: AlgoTypeNone, Location: LocationMAX}
+	resp, err := client.receive(ctx, client.sling.New().Get("").QueryStruct(params), &stats)
+	if err != nil {
+		return nil, err
+	}
+	if err = client.checkResult(params.Method, resp, stats.Result.Error); err != nil {
+		return nil, err
+	}
+	return stats.Result.MultiAlgo, nil
+}
+
+func (client *NicehashClient) GetSimpleMultiAlgoInfo() ([]MultiAlgo, error) {
+	return client.GetSimpleMultiAlgoInfoContext(context.Background())
+}
+
+func (client *NicehashClient) GetSimpleMultiAlgoInfoContext(ctx context.Context) ([]MultiAlgo, error) {
+	stats := &struct {
+		Result struct {
+			Error           string      `json:"error"`
+			SimpleMultiAlgo []MultiAlgo `json:"simplemultialgo"`
+		} `json:"result"`
+	}{}
+	params := &Params{Method: "simplemultialgo.info", Algo: AlgoTypeNone, Location: LocationMAX}
+	resp, err := client.receive(ctx, client.sling.New().Get("").QueryStruct(params), &stats)
+	if err != nil {
+		return nil, err
+	}
+	if err = client.checkResult(params.Method, resp, stats.Result.Error); err != nil {
+		return nil, err
+	}
+	return stats.Result.SimpleMultiAlgo, nil
+}
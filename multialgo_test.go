@@ -0,0 +1,89 @@
+package nicehash
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetMultiAlgoInfo(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	sampleItem := `{
+	   "result":{
+	      "multialgo":[
+		 {
+		    "paying":"0.00051495",
+		    "port":3333,
+		    "name":"scrypt",
+		    "algo":0
+		 },
+		 {
+		    "paying":"0.0119",
+		    "port":3353,
+		    "name":"daggerhashimoto",
+		    "algo":20
+		 }
+	      ]
+	   },
+	   "method":"multialgo.info"
+	}`
+
+	expectedItem := []MultiAlgo{
+		{
+			Paying: 51495,
+			Port: 3333,
+			Name: "scrypt",
+			Algo: AlgoTypeScrypt,
+		},
+		{
+			Paying: 1190000,
+			Port: 3353,
+			Name: "daggerhashimoto",
+			Algo: AlgoTypeDaggerHashimoto,
+		},
+	}
+
+	mux.HandleFunc("/api", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "multialgo.info", r.URL.Query().Get("method"))
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, sampleItem)
+	})
+
+	nicehashClient := NewNicehashClient(httpClient, "", "", "", "")
+	stats, err := nicehashClient.GetMultiAlgoInfo()
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedItem, stats)
+
+	url, err := stats[1].StratumURL(LocationWestHash)
+	assert.Nil(t, err)
+	assert.Equal(t, "stratum+tcp://daggerhashimoto.usa.nicehash.com:3353", url)
+	host, err := stats[0].StratumHost(LocationNiceHash)
+	assert.Nil(t, err)
+	assert.Equal(t, "scrypt.eu.nicehash.com", host)
+	_, err = stats[0].StratumHost(LocationMAX)
+	assert.NotNil(t, err)
+}
+
+func TestGetSimpleMultiAlgoInfo(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	sampleItem := `{"result":{"simplemultialgo":[{"paying":"0.0505","port":3334,"name":"sha256","algo":1}]},"method":"simplemultialgo.info"}`
+
+	mux.HandleFunc("/api", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "simplemultialgo.info", r.URL.Query().Get("method"))
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, sampleItem)
+	})
+
+	nicehashClient := NewNicehashClient(httpClient, "", "", "", "")
+	stats, err := nicehashClient.GetSimpleMultiAlgoInfo()
+
+	assert.Nil(t, err)
+	assert.Equal(t, []MultiAlgo{{Paying: 5050000, Port: 3334, Name: "sha256", Algo: AlgoTypeSHA256}}, stats)
+}
@@ -1,18 +1,25 @@
 package nicehash
 
 import (
+	"context"
+	"crypto/x509"
+	"errors"
 	"github.com/dghubble/sling"
-	"crypto/tls"
+	"io"
 	"net/http"
-	"net/http/httputil"
-	"log"
+	"net/url"
 	"strings"
+	"time"
 )
 
 type NicehashClient struct {
 	sling      *sling.Sling
+	baseURL    string
 	apiid      string
 	apikey     string
+	timeout    time.Duration
+	location   *time.Location
+	quoter     FixedPriceQuoter
 	httpClient *nicehashHttpClient
 }
 
@@ -22,64 +29,68 @@ type nicehashHttpClient struct {
 	client    *http.Client
 	debug     bool
 	useragent string
+	limiter   *RateLimiter
+	retry     *RetryPolicy
+	logger    Logger
+	redact    []string
+
+	// tls settings applied to a private copy of the transport,
+	// the caller's transport is never modified
+	tlsClient          *http.Client
+	insecureSkipVerify bool
+	rootCAs            *x509.CertPool
+	pins               [][]byte
 }
 
 type Params struct {
-	Method   string `url:"method"`
-	ApiId    string `url:"id,omitempty"`
-	ApiKey   string `url:"key,omitempty"`
-	Addr     string `url:"addr,omitempty"`
+	Method   string   `url:"method"`
+	ApiId    string   `url:"id,omitempty"`
+	ApiKey   string   `url:"key,omitempty"`
+	Addr     string   `url:"addr,omitempty"`
 	Algo     AlgoType `url:"algo"`
 	Location Location `url:"location"`
-	My       bool `url:"my,omitempty"`
+	My       bool     `url:"my,omitempty"`
+
+	Order  uint       `url:"order,omitempty"`
+	Limit  SpeedLimit `url:"limit,omitempty"`
+	Price  Price      `url:"price,omitempty"`
+	Amount Amount     `url:"amount,omitempty"`
 
-	Order    uint `url:"order,omitempty"`
-	Limit    float32 `url:"limit,omitempty"`
-	Price    float32 `url:"price,omitempty"`
-	Amount   float64 `url:"amount,omitempty"`
+	// From is a unix timestamp, the start of the requested history.
+	From int64 `url:"from,omitempty"`
 }
 
 func (d nicehashHttpClient) Do(req *http.Request) (*http.Response, error) {
-	if d.debug {
-		d.dumpRequest(req)
-	}
 	if d.useragent != "" {
 		req.Header.Set("User-Agent", d.useragent)
 	}
-	client := func() (*http.Client) {
-		if d.client != nil {
+	client := func() *http.Client {
+		if d.tlsClient != nil {
+			return d.tlsClient
+		} else if d.client != nil {
 			return d.client
 		} else {
 			return http.DefaultClient
 		}
 	}()
-	if client.Transport != nil {
-		if transport, ok := client.Transport.(*http.Transport); ok {
-			if transport.TLSClientConfig != nil {
-				transport.TLSClientConfig.InsecureSkipVerify = true;
-			} else {
-				transport.TLSClientConfig = &tls.Config{
-					InsecureSkipVerify: true,
-				}
-			}
+	attempts := d.retry.attempts(req.URL.Query().Get("method"))
+	var resp *http.Response
+	var err error
+	for attempt := 1; ; attempt++ {
+		resp, err = d.send(client, req)
+		if attempt >= attempts || !d.retry.retryable(resp, err) {
+			break
 		}
-	} else {
-		if transport, ok := http.DefaultTransport.(*http.Transport); ok {
-			if transport.TLSClientConfig != nil {
-				transport.TLSClientConfig.InsecureSkipVerify = true;
-			} else {
-				transport.TLSClientConfig = &tls.Config{
-					InsecureSkipVerify: true,
-				}
-			}
+		if err == nil {
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		if err = d.retry.wait(req.Context(), attempt); err != nil {
+			return nil, err
 		}
-	}
-	resp, err := client.Do(req)
-	if d.debug {
-		d.dumpResponse(resp)
 	}
 	if err == nil {
-		contenttype := resp.Header.Get("Content-Type");
+		contenttype := resp.Header.Get("Content-Type")
 		if len(contenttype) == 0 || strings.HasPrefix(contenttype, "text/html") {
 			resp.Header.Set("Content-Type", "application/json")
 		}
@@ -87,43 +98,61 @@ func (d nicehashHttpClient) Do(req *http.Request) (*http.Response, error) {
 	return resp, err
 }
 
-func (d nicehashHttpClient) dumpRequest(r *http.Request) {
-	if r == nil {
-		log.Print("dumpReq ok: <nil>")
-		return
+// send makes a single attempt of req within the rate limits.
+func (d nicehashHttpClient) send(client *http.Client, req *http.Request) (*http.Response, error) {
+	if d.debug {
+		d.dumpRequest(req)
 	}
-	dump, err := httputil.DumpRequest(r, true)
-	if err != nil {
-		log.Print("dumpReq err:", err)
-	} else {
-		log.Print("dumpReq ok:", string(dump))
+	class := rateLimitClass(req)
+	if d.limiter != nil {
+		if err := d.limiter.Wait(req.Context(), class); err != nil {
+			return nil, err
+		}
 	}
-}
-
-func (d nicehashHttpClient) dumpResponse(r *http.Response) {
-	if r == nil {
-		log.Print("dumpResponse ok: <nil>")
-		return
+	start := time.Now()
+	resp, err := client.Do(req)
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		// the url of the error carries the credentials
+		urlErr.URL = d.redactURL(req.URL).String()
 	}
-	dump, err := httputil.DumpResponse(r, true)
-	if err != nil {
-		log.Print("dumpResponse err:", err)
-	} else {
-		log.Print("dumpResponse ok:", string(dump))
+	d.logRequest(req, resp, err, time.Since(start))
+	if err == nil && resp.StatusCode == http.StatusTooManyRequests && d.limiter != nil {
+		d.limiter.Backoff(class, retryAfter(resp))
 	}
+	if d.debug {
+		d.dumpResponse(req, resp)
+	}
+	return resp, err
 }
 
+// NewNicehashClient returns a client using the given http client (nil means
+// http.DefaultClient), base url (empty means DefaultBaseURL), credentials
+// and user agent. See New for the other settings.
 func NewNicehashClient(client *http.Client, BaseURL string, ApiId string, ApiKey string, UserAgent string) *NicehashClient {
-	if len(BaseURL) == 0 {
-		BaseURL = "https://api.nicehash.com/"
+	// none of these options can fail
+	nicehashClient, _ := New(
+		WithHTTPClient(client),
+		WithBaseURL(BaseURL),
+		WithCredentials(ApiId, ApiKey),
+		WithUserAgent(UserAgent),
+	)
+	return nicehashClient
+}
+
+// receive sends the request built by s bound to ctx and decodes a successful
+// response into success.
+func (client *NicehashClient) receive(ctx context.Context, s *sling.Sling, success interface{}) (*http.Response, error) {
+	req, err := s.Request()
+	if err != nil {
+		return nil, err
 	}
-	nicehashclient := &nicehashHttpClient{client:client, useragent:UserAgent}
-	return &NicehashClient{
-		httpClient: nicehashclient,
-		sling: sling.New().Doer(nicehashclient).Base(strings.TrimRight(BaseURL, "/") + "/").Path("api"),
-		apiid: ApiId,
-		apikey: ApiKey,
+	if client.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, client.timeout)
+		defer cancel()
 	}
+	return s.Do(req.WithContext(ctx), success, nil)
 }
 
 func (client NicehashClient) SetDebug(debug bool) {
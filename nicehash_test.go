@@ -1,9 +1,14 @@
 package nicehash
 
 import (
+	"context"
+	"errors"
 	"net/http/httptest"
 	"net/url"
 	"net/http"
+	"testing"
+	"time"
+	"github.com/stretchr/testify/assert"
 )
 
 // Use the client to make requests on the server.
@@ -33,3 +38,19 @@ func (t *RewriteTransport) RoundTrip(req *http.Request) (*http.Response, error)
 	}
 	return t.Transport.RoundTrip(req)
 }
+
+func TestContextCancel(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	mux.HandleFunc("/api", func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	})
+
+	nicehashClient := NewNicehashClient(httpClient, "", "FAKEID", "FAKEKEY", "")
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := nicehashClient.GetBalanceContext(ctx)
+
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
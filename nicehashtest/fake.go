@@ -0,0 +1,342 @@
+// Package nicehashtest provides a fake nicehash.NicehashAPI for tests.
+package nicehashtest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/bitbandi/go-nicehash-api"
+	"sync"
+	"time"
+)
+
+// ErrNotScripted is returned by the methods of Fake without function.
+var ErrNotScripted = errors.New("nicehashtest: method not scripted")
+
+// Call is a call made to a Fake. Method is the name of the method without
+// the Context suffix, Args are its arguments without the context.
+type Call struct {
+	Method string
+	Args   []interface{}
+}
+
+// Fake implements nicehash.NicehashAPI with the functions of its fields,
+// the responses and errors of the methods are scripted by setting them.
+// The methods without function return ErrNotScripted. Both variants of a
+// method, with and without Context, call the same function. Fake records
+// the calls and is safe for concurrent use.
+//
+//	fake := &nicehashtest.Fake{
+//		GetBalanceFunc: func(ctx context.Context) (nicehash.Balance, error) {
+//			return nicehash.Balance{Confirmed: 500000}, nil
+//		},
+//	}
+type Fake struct {
+	GetBalanceFunc              func(ctx context.Context) (nicehash.Balance, error)
+	GetBuyInfoFunc              func(ctx context.Context) (nicehash.BuyInfo, error)
+	GetMultiAlgoInfoFunc        func(ctx context.Context) ([]nicehash.MultiAlgo, error)
+	GetSimpleMultiAlgoInfoFunc  func(ctx context.Context) ([]nicehash.MultiAlgo, error)
+	GetOrdersFunc               func(ctx context.Context, algo nicehash.AlgoType, location nicehash.Location) ([]nicehash.Orders, error)
+	GetMyOrdersFunc             func(ctx context.Context, algo nicehash.AlgoType, location nicehash.Location) ([]nicehash.MyOrders, error)
+	OrderCreateFunc             func(ctx context.Context, order nicehash.NewOrder) (string, error)
+	GetFixedPriceFunc           func(ctx context.Context, algo nicehash.AlgoType, location nicehash.Location, limit nicehash.SpeedLimit) (nicehash.FixedPriceQuote, error)
+	OrderRefillFunc             func(ctx context.Context, algo nicehash.AlgoType, location nicehash.Location, order uint, amount nicehash.Amount) (string, error)
+	OrderRemoveFunc             func(ctx context.Context, algo nicehash.AlgoType, location nicehash.Location, order uint) (string, error)
+	OrderSetPriceFunc           func(ctx context.Context, algo nicehash.AlgoType, location nicehash.Location, order uint, price nicehash.Price) (string, error)
+	OrderSetPriceDecreaseFunc   func(ctx context.Context, algo nicehash.AlgoType, location nicehash.Location, order uint) (string, error)
+	OrderSetLimitFunc           func(ctx context.Context, algo nicehash.AlgoType, location nicehash.Location, order uint, limit nicehash.SpeedLimit) (string, error)
+	GetStatsGlobalCurrentFunc   func(ctx context.Context) ([]nicehash.GlobalStats, error)
+	GetStatsGlobalDayFunc       func(ctx context.Context) ([]nicehash.GlobalStats, error)
+	GetStatsProviderFunc        func(ctx context.Context, addr string) ([]nicehash.ProviderStats, []nicehash.ProviderPayments, error)
+	GetStatsProviderExFunc      func(ctx context.Context, addr string) (nicehash.StatsProviderEx, error)
+	GetStatsProviderExSinceFunc func(ctx context.Context, addr string, from time.Time) (nicehash.StatsProviderEx, error)
+	GetStatsProviderWorkersFunc func(ctx context.Context, addr string, algo nicehash.AlgoType) ([]nicehash.ProviderWorker, error)
+	GetVersionFunc              func(ctx context.Context) (string, error)
+
+	mu    sync.Mutex
+	calls []Call
+}
+
+var _ nicehash.NicehashAPI = (*Fake)(nil)
+
+func (f *Fake) record(method string, args ...interface{}) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, Call{Method: method, Args: args})
+}
+
+// Calls returns the calls made so far, in order.
+func (f *Fake) Calls() []Call {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]Call(nil), f.calls...)
+}
+
+// CallsTo returns the calls made so far to method, eg. "GetBalance".
+func (f *Fake) CallsTo(method string) []Call {
+	var calls []Call
+	for _, call := range f.Calls() {
+		if call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// Reset forgets the calls made so far.
+func (f *Fake) Reset() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = nil
+}
+
+func notScripted(method string) error {
+	return fmt.Errorf("%w: %s", ErrNotScripted, method)
+}
+
+func (f *Fake) GetBalance() (nicehash.Balance, error) {
+	return f.GetBalanceContext(context.Background())
+}
+
+func (f *Fake) GetBalanceContext(ctx context.Context) (nicehash.Balance, error) {
+	f.record("GetBalance")
+	if f.GetBalanceFunc == nil {
+		return nicehash.Balance{}, notScripted("GetBalance")
+	}
+	return f.GetBalanceFunc(ctx)
+}
+
+func (f *Fake) GetBuyInfo() (nicehash.BuyInfo, error) {
+	return f.GetBuyInfoContext(context.Background())
+}
+
+func (f *Fake) GetBuyInfoContext(ctx context.Context) (nicehash.BuyInfo, error) {
+	f.record("GetBuyInfo")
+	if f.GetBuyInfoFunc == nil {
+		return nicehash.BuyInfo{}, notScripted("GetBuyInfo")
+	}
+	return f.GetBuyInfoFunc(ctx)
+}
+
+func (f *Fake) GetMultiAlgoInfo() ([]nicehash.MultiAlgo, error) {
+	return f.GetMultiAlgoInfoContext(context.Background())
+}
+
+func (f *Fake) GetMultiAlgoInfoContext(ctx context.Context) ([]nicehash.MultiAlgo, error) {
+	f.record("GetMultiAlgoInfo")
+	if f.GetMultiAlgoInfoFunc == nil {
+		return nil, notScripted("GetMultiAlgoInfo")
+	}
+	return f.GetMultiAlgoInfoFunc(ctx)
+}
+
+func (f *Fake) GetSimpleMultiAlgoInfo() ([]nicehash.MultiAlgo, error) {
+	return f.GetSimpleMultiAlgoInfoContext(context.Background())
+}
+
+func (f *Fake) GetSimpleMultiAlgoInfoContext(ctx context.Context) ([]nicehash.MultiAlgo, error) {
+	f.record("GetSimpleMultiAlgoInfo")
+	if f.GetSimpleMultiAlgoInfoFunc == nil {
+		return nil, notScripted("GetSimpleMultiAlgoInfo")
+	}
+	return f.GetSimpleMultiAlgoInfoFunc(ctx)
+}
+
+func (f *Fake) GetOrders(algo nicehash.AlgoType, location nicehash.Location) ([]nicehash.Orders, error) {
+	return f.GetOrdersContext(context.Background(), algo, location)
+}
+
+func (f *Fake) GetOrdersContext(ctx context.Context, algo nicehash.AlgoType, location nicehash.Location) ([]nicehash.Orders, error) {
+	f.record("GetOrders", algo, location)
+	if f.GetOrdersFunc == nil {
+		return nil, notScripted("GetOrders")
+	}
+	return f.GetOrdersFunc(ctx, algo, location)
+}
+
+func (f *Fake) GetMyOrders(algo nicehash.AlgoType, location nicehash.Location) ([]nicehash.MyOrders, error) {
+	return f.GetMyOrdersContext(context.Background(), algo, location)
+}
+
+func (f *Fake) GetMyOrdersContext(ctx context.Context, algo nicehash.AlgoType, location nicehash.Location) ([]nicehash.MyOrders, error) {
+	f.record("GetMyOrders", algo, location)
+	if f.GetMyOrdersFunc == nil {
+		return nil, notScripted("GetMyOrders")
+	}
+	return f.GetMyOrdersFunc(ctx, algo, location)
+}
+
+func (f *Fake) OrderCreate(order nicehash.NewOrder) (string, error) {
+	return f.OrderCreateContext(context.Background(), order)
+}
+
+func (f *Fake) OrderCreateContext(ctx context.Context, order nicehash.NewOrder) (string, error) {
+	f.record("OrderCreate", order)
+	if f.OrderCreateFunc == nil {
+		return "", notScripted("OrderCreate")
+	}
+	return f.OrderCreateFunc(ctx, order)
+}
+
+func (f *Fake) GetFixedPrice(algo nicehash.AlgoType, location nicehash.Location, limit nicehash.SpeedLimit) (nicehash.FixedPriceQuote, error) {
+	return f.GetFixedPriceContext(context.Background(), algo, location, limit)
+}
+
+func (f *Fake) GetFixedPriceContext(ctx context.Context, algo nicehash.AlgoType, location nicehash.Location, limit nicehash.SpeedLimit) (nicehash.FixedPriceQuote, error) {
+	f.record("GetFixedPrice", algo, location, limit)
+	if f.GetFixedPriceFunc == nil {
+		return nicehash.FixedPriceQuote{}, notScripted("GetFixedPrice")
+	}
+	return f.GetFixedPriceFunc(ctx, algo, location, limit)
+}
+
+func (f *Fake) OrderRefill(algo nicehash.AlgoType, location nicehash.Location, order uint, amount nicehash.Amount) (string, error) {
+	return f.OrderRefillContext(context.Background(), algo, location, order, amount)
+}
+
+func (f *Fake) OrderRefillContext(ctx context.Context, algo nicehash.AlgoType, location nicehash.Location, order uint, amount nicehash.Amount) (string, error) {
+	f.record("OrderRefill", algo, location, order, amount)
+	if f.OrderRefillFunc == nil {
+		return "", notScripted("OrderRefill")
+	}
+	return f.OrderRefillFunc(ctx, algo, location, order, amount)
+}
+
+func (f *Fake) OrderRemove(algo nicehash.AlgoType, location nicehash.Location, order uint) (string, error) {
+	return f.OrderRemoveContext(context.Background(), algo, location, order)
+}
+
+func (f *Fake) OrderRemoveContext(ctx context.Context, algo nicehash.AlgoType, location nicehash.Location, order uint) (string, error) {
+	f.record("OrderRemove", algo, location, order)
+	if f.OrderRemoveFunc == nil {
+		return "", notScripted("OrderRemove")
+	}
+	return f.OrderRemoveFunc(ctx, algo, location, order)
+}
+
+func (f *Fake) OrderSetPrice(algo nicehash.AlgoType, location nicehash.Location, order uint, price nicehash.Price) (string, error) {
+	return f.OrderSetPriceContext(context.Background(), algo, location, order, price)
+}
+
+func (f *Fake) OrderSetPriceContext(ctx context.Context, algo nicehash.AlgoType, location nicehash.Location, order uint, price nicehash.Price) (string, error) {
+	f.record("OrderSetPrice", algo, location, order, price)
+	if f.OrderSetPriceFunc == nil {
+		return "", notScripted("OrderSetPrice")
+	}
+	return f.OrderSetPriceFunc(ctx, algo, location, order, price)
+}
+
+func (f *Fake) OrderSetPriceDecrease(algo nicehash.AlgoType, location nicehash.Location, order uint) (string, error) {
+	return f.OrderSetPriceDecreaseContext(context.Background(), algo, location, order)
+}
+
+func (f *Fake) OrderSetPriceDecreaseContext(ctx context.Context, algo nicehash.AlgoType, location nicehash.Location, order uint) (string, error) {
+	f.record("OrderSetPriceDecrease", algo, location, order)
+	if f.OrderSetPriceDecreaseFunc == nil {
+		return "", notScripted("OrderSetPriceDecrease")
+	}
+	return f.OrderSetPriceDecreaseFunc(ctx, algo, location, order)
+}
+
+func (f *Fake) OrderSetLimit(algo nicehash.AlgoType, location nicehash.Location, order uint, limit nicehash.SpeedLimit) (string, error) {
+	return f.OrderSetLimitContext(context.Background(), algo, location, order, limit)
+}
+
+func (f *Fake) OrderSetLimitContext(ctx context.Context, algo nicehash.AlgoType, location nicehash.Location, order uint, limit nicehash.SpeedLimit) (string, error) {
+	f.record("OrderSetLimit", algo, location, order, limit)
+	if f.OrderSetLimitFunc == nil {
+		return "", notScripted("OrderSetLimit")
+	}
+	return f.OrderSetLimitFunc(ctx, algo, location, order, limit)
+}
+
+func (f *Fake) GetStatsGlobalCurrent() ([]nicehash.GlobalStats, error) {
+	return f.GetStatsGlobalCurrentContext(context.Background())
+}
+
+func (f *Fake) GetStatsGlobalCurrentContext(ctx context.Context) ([]nicehash.GlobalStats, error) {
+	f.record("GetStatsGlobalCurrent")
+	if f.GetStatsGlobalCurrentFunc == nil {
+		return nil, notScripted("GetStatsGlobalCurrent")
+	}
+	return f.GetStatsGlobalCurrentFunc(ctx)
+}
+
+func (f *Fake) GetStatsGlobalDay() ([]nicehash.GlobalStats, error) {
+	return f.GetStatsGlobalDayContext(context.Background())
+}
+
+func (f *Fake) GetStatsGlobalDayContext(ctx context.Context) ([]nicehash.GlobalStats, error) {
+	f.record("GetStatsGlobalDay")
+	if f.GetStatsGlobalDayFunc == nil {
+		return nil, notScripted("GetStatsGlobalDay")
+	}
+	return f.GetStatsGlobalDayFunc(ctx)
+}
+
+func (f *Fake) GetStatsProvider(addr string) ([]nicehash.ProviderStats, []nicehash.ProviderPayments, error) {
+	return f.GetStatsProviderContext(context.Background(), addr)
+}
+
+func (f *Fake) GetStatsProviderContext(ctx context.Context, addr string) ([]nicehash.ProviderStats, []nicehash.ProviderPayments, error) {
+	f.record("GetStatsProvider", addr)
+	if f.GetStatsProviderFunc == nil {
+		return nil, nil, notScripted("GetStatsProvider")
+	}
+	return f.GetStatsProviderFunc(ctx, addr)
+}
+
+func (f *Fake) GetStatsProviderEx(addr string) (nicehash.StatsProviderEx, error) {
+	return f.GetStatsProviderExContext(context.Background(), addr)
+}
+
+func (f *Fake) GetStatsProviderExContext(ctx context.Context, addr string) (nicehash.StatsProviderEx, error) {
+	f.record("GetStatsProviderEx", addr)
+	if f.GetStatsProviderExFunc == nil {
+		return nicehash.StatsProviderEx{}, notScripted("GetStatsProviderEx")
+	}
+	return f.GetStatsProviderExFunc(ctx, addr)
+}
+
+func (f *Fake) GetStatsProviderExSince(addr string, from time.Time) (nicehash.StatsProviderEx, error) {
+	return f.GetStatsProviderExSinceContext(context.Background(), addr, from)
+}
+
+func (f *Fake) GetStatsProviderExSinceContext(ctx context.Context, addr string, from time.Time) (nicehash.StatsProviderEx, error) {
+	f.record("GetStatsProviderExSince", addr, from)
+	if f.GetStatsProviderExSinceFunc == nil {
+		return nicehash.StatsProviderEx{}, notScripted("GetStatsProviderExSince")
+	}
+	return f.GetStatsProviderExSinceFunc(ctx, addr, from)
+}
+
+// ProviderExHistory returns an iterator over the fake, its windows are
+// scripted by GetStatsProviderExSinceFunc.
+func (f *Fake) ProviderExHistory(addr string, since time.Time, window time.Duration) *nicehash.ProviderExHistoryIterator {
+	f.record("ProviderExHistory", addr, since, window)
+	return nicehash.NewProviderExHistoryIterator(f, addr, since, window)
+}
+
+func (f *Fake) GetStatsProviderWorkers(addr string, algo nicehash.AlgoType) ([]nicehash.ProviderWorker, error) {
+	return f.GetStatsProviderWorkersContext(context.Background(), addr, algo)
+}
+
+func (f *Fake) GetStatsProviderWorkersContext(ctx context.Context, addr string, algo nicehash.AlgoType) ([]nicehash.ProviderWorker, error) {
+	f.record("GetStatsProviderWorkers", addr, algo)
+	if f.GetStatsProviderWorkersFunc == nil {
+		return nil, notScripted("GetStatsProviderWorkers")
+	}
+	return f.GetStatsProviderWorkersFunc(ctx, addr, algo)
+}
+
+func (f *Fake) GetVersion() (string, error) {
+	return f.GetVersionContext(context.Background())
+}
+
+func (f *Fake) GetVersionContext(ctx context.Context) (string, error) {
+	f.record("GetVersion")
+	if f.GetVersionFunc == nil {
+		return "", notScripted("GetVersion")
+	}
+	return f.GetVersionFunc(ctx)
+}
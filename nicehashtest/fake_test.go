@@ -0,0 +1,63 @@
+package nicehashtest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"github.com/bitbandi/go-nicehash-api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFake(t *testing.T) {
+	fake := &Fake{
+		GetBalanceFunc: func(ctx context.Context) (nicehash.Balance, error) {
+			return nicehash.Balance{Confirmed: 500000}, nil
+		},
+		OrderSetPriceFunc: func(ctx context.Context, algo nicehash.AlgoType, location nicehash.Location, order uint, price nicehash.Price) (string, error) {
+			return "", errors.New("order not found")
+		},
+	}
+	var api nicehash.NicehashAPI = fake
+
+	balance, err := api.GetBalance()
+	assert.Nil(t, err)
+	assert.Equal(t, nicehash.Balance{Confirmed: 500000}, balance)
+
+	_, err = api.OrderSetPriceContext(context.Background(), nicehash.AlgoTypeSHA256, nicehash.LocationNiceHash, 123, 5050000)
+	assert.EqualError(t, err, "order not found")
+
+	_, err = api.GetVersion()
+	assert.True(t, errors.Is(err, ErrNotScripted))
+
+	assert.Equal(t, []Call{
+		{Method: "GetBalance"},
+		{Method: "OrderSetPrice", Args: []interface{}{nicehash.AlgoTypeSHA256, nicehash.LocationNiceHash, uint(123), nicehash.Price(5050000)}},
+		{Method: "GetVersion"},
+	}, fake.Calls())
+	assert.Len(t, fake.CallsTo("GetBalance"), 1)
+
+	fake.Reset()
+	assert.Empty(t, fake.Calls())
+}
+
+func TestFakeHistoryIterator(t *testing.T) {
+	fake := &Fake{
+		GetStatsProviderExSinceFunc: func(ctx context.Context, addr string, from time.Time) (nicehash.StatsProviderEx, error) {
+			return nicehash.StatsProviderEx{Past: []nicehash.ProviderExHistory{{
+				Algo: nicehash.AlgoTypeSHA256,
+				Data: nicehash.HistorySeries{{Time: from.Truncate(nicehash.HistoryInterval), AcceptedSpeed: 1}},
+			}}}, nil
+		},
+	}
+
+	var api nicehash.NicehashAPI = fake
+	it := api.ProviderExHistory("FAKEADDR", time.Now().Add(-150*time.Minute), time.Hour)
+	for it.Next(context.Background()) {
+	}
+
+	assert.Nil(t, it.Err())
+	assert.Len(t, fake.CallsTo("ProviderExHistory"), 1)
+	assert.Len(t, fake.CallsTo("GetStatsProviderExSince"), 3)
+	assert.Len(t, it.History()[nicehash.AlgoTypeSHA256], 3)
+}
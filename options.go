@@ -0,0 +1,159 @@
+package nicehash
+
+import (
+	"crypto/x509"
+	"github.com/dghubble/sling"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const DefaultBaseURL = "https://api.nicehash.com/"
+
+// Option configures a NicehashClient created by New.
+type Option func(*NicehashClient) error
+
+// New returns a client configured by opts. Without options it talks to
+// DefaultBaseURL through http.DefaultClient, can only call public methods
+// and is limited by DefaultPublicRateLimit and DefaultPrivateRateLimit.
+func New(opts ...Option) (*NicehashClient, error) {
+	nicehashclient := &nicehashHttpClient{
+		limiter: NewRateLimiter(DefaultPublicRateLimit, DefaultPrivateRateLimit),
+		redact:  DefaultRedactedParams,
+	}
+	client := &NicehashClient{
+		httpClient: nicehashclient,
+		baseURL:    DefaultBaseURL,
+	}
+	for _, opt := range opts {
+		if err := opt(client); err != nil {
+			return nil, err
+		}
+	}
+	client.sling = sling.New().Doer(nicehashclient).Base(strings.TrimRight(client.baseURL, "/") + "/").Path("api")
+	return client, nil
+}
+
+// WithHTTPClient sets the http client used to send the requests.
+// The client is never modified.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(client *NicehashClient) error {
+		client.httpClient.client = httpClient
+		return client.httpClient.configureTLS()
+	}
+}
+
+// WithBaseURL sets the url of the api server, empty means DefaultBaseURL.
+func WithBaseURL(baseURL string) Option {
+	return func(client *NicehashClient) error {
+		if baseURL != "" {
+			client.baseURL = baseURL
+		}
+		return nil
+	}
+}
+
+// WithCredentials sets the api id and key needed by the private methods.
+func WithCredentials(apiId string, apiKey string) Option {
+	return func(client *NicehashClient) error {
+		client.apiid = apiId
+		client.apikey = apiKey
+		return nil
+	}
+}
+
+// WithUserAgent sets the User-Agent header of the requests.
+func WithUserAgent(userAgent string) Option {
+	return func(client *NicehashClient) error {
+		client.httpClient.useragent = userAgent
+		return nil
+	}
+}
+
+// WithLogger sets the logger, see SetLogger.
+func WithLogger(logger Logger) Option {
+	return func(client *NicehashClient) error {
+		client.SetLogger(logger)
+		return nil
+	}
+}
+
+// WithDebug enables the request and response dumps, see SetDebug.
+func WithDebug(debug bool) Option {
+	return func(client *NicehashClient) error {
+		client.SetDebug(debug)
+		return nil
+	}
+}
+
+// WithRedactedParams sets the parameters hidden in the logs, see SetRedactedParams.
+func WithRedactedParams(params ...string) Option {
+	return func(client *NicehashClient) error {
+		client.SetRedactedParams(params...)
+		return nil
+	}
+}
+
+// WithTimeout limits the duration of every call, including retries and
+// rate limit waits. Zero means no limit.
+func WithTimeout(timeout time.Duration) Option {
+	return func(client *NicehashClient) error {
+		client.timeout = timeout
+		return nil
+	}
+}
+
+// WithServerLocation sets the time zone of the server, see SetServerLocation.
+func WithServerLocation(loc *time.Location) Option {
+	return func(client *NicehashClient) error {
+		client.SetServerLocation(loc)
+		return nil
+	}
+}
+
+// WithFixedPriceQuoter sets the quoter of GetFixedPrice, eg. an
+// apiv2.Client, see SetFixedPriceQuoter.
+func WithFixedPriceQuoter(quoter FixedPriceQuoter) Option {
+	return func(client *NicehashClient) error {
+		client.SetFixedPriceQuoter(quoter)
+		return nil
+	}
+}
+
+// WithRateLimiter sets the rate limiter, see SetRateLimiter.
+func WithRateLimiter(limiter *RateLimiter) Option {
+	return func(client *NicehashClient) error {
+		client.SetRateLimiter(limiter)
+		return nil
+	}
+}
+
+// WithRetryPolicy sets the retry policy, see SetRetryPolicy.
+func WithRetryPolicy(policy *RetryPolicy) Option {
+	return func(client *NicehashClient) error {
+		client.SetRetryPolicy(policy)
+		return nil
+	}
+}
+
+// WithInsecureSkipVerify disables the verification of the server
+// certificate, see SetInsecureSkipVerify.
+func WithInsecureSkipVerify(skip bool) Option {
+	return func(client *NicehashClient) error {
+		return client.SetInsecureSkipVerify(skip)
+	}
+}
+
+// WithRootCAs sets the root certificate authorities, see SetRootCAs.
+func WithRootCAs(pool *x509.CertPool) Option {
+	return func(client *NicehashClient) error {
+		return client.SetRootCAs(pool)
+	}
+}
+
+// WithCertificatePins pins the server public keys, see SetCertificatePins.
+func WithCertificatePins(pins ...string) Option {
+	return func(client *NicehashClient) error {
+		return client.SetCertificatePins(pins...)
+	}
+}
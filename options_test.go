@@ -0,0 +1,61 @@
+package nicehash
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	sampleItem := `{"result":{"balance_confirmed":"0.00500000","balance_pending":"0.00000000"},"method":"balance"}`
+
+	mux.HandleFunc("/custom/api", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "FAKEID", r.URL.Query().Get("id"))
+		assert.Equal(t, "FAKEKEY", r.URL.Query().Get("key"))
+		assert.Equal(t, "test-agent", r.Header.Get("User-Agent"))
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, sampleItem)
+	})
+
+	nicehashClient, err := New(
+		WithHTTPClient(httpClient),
+		WithBaseURL("https://api.nicehash.com/custom/"),
+		WithCredentials("FAKEID", "FAKEKEY"),
+		WithUserAgent("test-agent"),
+		WithRateLimiter(nil),
+	)
+	assert.Nil(t, err)
+	balance, err := nicehashClient.GetBalance()
+
+	assert.Nil(t, err)
+	assert.Equal(t, Balance{Confirmed: 500000}, balance)
+}
+
+func TestNewTimeout(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	mux.HandleFunc("/api", func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	})
+
+	nicehashClient, err := New(WithHTTPClient(httpClient), WithTimeout(50*time.Millisecond))
+	assert.Nil(t, err)
+	_, err = nicehashClient.GetStatsGlobalCurrent()
+
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
+
+func TestNewInvalidOption(t *testing.T) {
+	nicehashClient, err := New(WithCertificatePins("not a pin"))
+
+	assert.Nil(t, nicehashClient)
+	assert.NotNil(t, err)
+}
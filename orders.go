@@ -1,163 +1,301 @@
 package nicehash
 
+import (
+	"context"
+	"errors"
+	"time"
+)
+
 type Orders struct {
-	Id            uint64 `json:"id"`
-	Type          OrderType `json:"type"`
-	Algo          AlgoType `json:"algo"`
-	Price         float64 `json:"price,string"`
-	Alive         bool `json:"alive"`
-	LimitSpeed    float64 `json:"limit_speed,string"`
-	AcceptedSpeed float64 `json:"accepted_speed,string"`
-	Workers       uint64 `json:"workers"`
+	Id            uint64     `json:"id"`
+	Type          OrderType  `json:"type"`
+	Algo          AlgoType   `json:"algo"`
+	Price         Price      `json:"price"`
+	Alive         bool       `json:"alive"`
+	LimitSpeed    SpeedLimit `json:"limit_speed"`
+	AcceptedSpeed float64    `json:"accepted_speed,string"`
+	Workers       uint64     `json:"workers"`
 }
 
 func (client *NicehashClient) GetOrders(algo AlgoType, location Location) ([]Orders, error) {
+	return client.GetOrdersContext(context.Background(), algo, location)
+}
+
+func (client *NicehashClient) GetOrdersContext(ctx context.Context, algo AlgoType, location Location) ([]Orders, error) {
 	stats := &struct {
 		Result struct {
-			       Orders []Orders `json:"orders"`
-		       } `json:"result"`
+			Error  string   `json:"error"`
+			Orders []Orders `json:"orders"`
+		} `json:"result"`
 	}{}
-	params := &Params{Method:"orders.get", Algo:algo, Location:location}
-	_, err := client.sling.New().Get("").QueryStruct(params).ReceiveSuccess(&stats)
+	params := &Params{Method: "orders.get", Algo: algo, Location: location}
+	resp, err := client.receive(ctx, client.sling.New().Get("").QueryStruct(params), &stats)
 	if err != nil {
 		return stats.Result.Orders, err
 	}
+	if err = client.checkResult(params.Method, resp, stats.Result.Error); err != nil {
+		return stats.Result.Orders, err
+	}
 
 	return stats.Result.Orders, nil
 }
 
 type MyOrders struct {
-	Id            uint64 `json:"id"`
-	Type          OrderType `json:"type"`
-	Algo          AlgoType `json:"algo"`
-	Price         float64 `json:"price,string"`
-	BtcAvail      float64 `json:"btc_avail,string"`
-	BtcPaid       float64 `json:"btc_paid,string"`
-	PoolHost      string `json:"pool_host"`
-	PoolPort      uint16 `json:"pool_port"`
-	PoolUser      string `json:"pool_user"`
-	PoolPass      string `json:"pool_pass"`
-	Alive         bool `json:"alive"`
-	LimitSpeed    float64 `json:"limit_speed,string"`
-	AcceptedSpeed float64 `json:"accepted_speed,string"`
-	Workers       uint64 `json:"workers"`
-	End           uint64 `json:"end"`
+	Id            uint64     `json:"id"`
+	Type          OrderType  `json:"type"`
+	Algo          AlgoType   `json:"algo"`
+	Price         Price      `json:"price"`
+	BtcAvail      Amount     `json:"btc_avail"`
+	BtcPaid       Amount     `json:"btc_paid"`
+	PoolHost      string     `json:"pool_host"`
+	PoolPort      uint16     `json:"pool_port"`
+	PoolUser      string     `json:"pool_user"`
+	PoolPass      string     `json:"pool_pass"`
+	Alive         bool       `json:"alive"`
+	LimitSpeed    SpeedLimit `json:"limit_speed"`
+	AcceptedSpeed float64    `json:"accepted_speed,string"`
+	Workers       uint64     `json:"workers"`
+	// End is the expiry of the order in unix milliseconds, see ExpiresAt.
+	End uint64 `json:"end"`
+}
+
+// IsFixed reports whether the order is a fixed order, bought for the fixed
+// Price and LimitSpeed until End.
+func (o MyOrders) IsFixed() bool {
+	return o.Type == OrderTypeFixed
+}
+
+// ExpiresAt returns the end of the order in UTC, the zero time when the
+// order has no end.
+func (o MyOrders) ExpiresAt() time.Time {
+	if o.End == 0 {
+		return time.Time{}
+	}
+	return unixMilliTime(int64(o.End))
+}
+
+// TimeRemaining returns the time until the end of the order, zero when it
+// is expired or has no end.
+func (o MyOrders) TimeRemaining() time.Duration {
+	if o.End == 0 {
+		return 0
+	}
+	if remaining := time.Until(o.ExpiresAt()); remaining > 0 {
+		return remaining
+	}
+	return 0
 }
 
 func (client *NicehashClient) GetMyOrders(algo AlgoType, location Location) ([]MyOrders, error) {
+	return client.GetMyOrdersContext(context.Background(), algo, location)
+}
+
+func (client *NicehashClient) GetMyOrdersContext(ctx context.Context, algo AlgoType, location Location) ([]MyOrders, error) {
 	stats := &struct {
 		Result struct {
-			       Orders []MyOrders `json:"orders"`
-		       } `json:"result"`
+			Error  string     `json:"error"`
+			Orders []MyOrders `json:"orders"`
+		} `json:"result"`
 	}{}
-	params := &Params{Method:"orders.get", Algo:algo, Location:location, My:true, ApiId:client.apiid, ApiKey:client.apikey}
-	_, err := client.sling.New().Get("").QueryStruct(params).ReceiveSuccess(&stats)
+	params := &Params{Method: "orders.get", Algo: algo, Location: location, My: true, ApiId: client.apiid, ApiKey: client.apikey}
+	resp, err := client.receive(ctx, client.sling.New().Get("").QueryStruct(params), &stats)
 	if err != nil {
 		return stats.Result.Orders, err
 	}
+	if err = client.checkResult(params.Method, resp, stats.Result.Error); err != nil {
+		return stats.Result.Orders, err
+	}
 
 	return stats.Result.Orders, nil
 }
 
 type NewOrder struct {
-	Algo       AlgoType `json:"algo" url:"algo"`
-	Price      float64 `json:"price,string" url:"price"`
-	Amount     float64 `json:"amount,string" url:"amount"`
-	PoolHost   string `json:"pool_host" url:"pool_host"`
-	PoolPort   uint16 `json:"pool_port" url:"pool_port"`
-	PoolUser   string `json:"pool_user" url:"pool_user"`
-	PoolPass   string `json:"pool_pass" url:"pool_pass"`
-	Alive      bool `json:"alive" url:"alive"`
-	LimitSpeed float64 `json:"limit,string" url:"limit,omitempty"`
-	Code       string `json:"code" url:"code,omitempty"`
+	Algo       AlgoType   `json:"algo" url:"algo"`
+	Type       OrderType  `json:"type" url:"type,omitempty"`
+	Price      Price      `json:"price" url:"price"`
+	Amount     Amount     `json:"amount" url:"amount"`
+	PoolHost   string     `json:"pool_host" url:"pool_host"`
+	PoolPort   uint16     `json:"pool_port" url:"pool_port"`
+	PoolUser   string     `json:"pool_user" url:"pool_user"`
+	PoolPass   string     `json:"pool_pass" url:"pool_pass"`
+	Alive      bool       `json:"alive" url:"alive"`
+	LimitSpeed SpeedLimit `json:"limit" url:"limit,omitempty"`
+	Code       string     `json:"code" url:"code,omitempty"`
 }
 
 func (client *NicehashClient) OrderCreate(order NewOrder) (string, error) {
+	return client.OrderCreateContext(context.Background(), order)
+}
+
+func (client *NicehashClient) OrderCreateContext(ctx context.Context, order NewOrder) (string, error) {
+	if order.Type == OrderTypeFixed && order.LimitSpeed == 0 {
+		return "", errors.New("nicehash: fixed order without speed limit")
+	}
 	stats := &struct {
 		Result struct {
-			       Success string `json:"success"`
-		       } `json:"result"`
+			Error   string `json:"error"`
+			Success string `json:"success"`
+		} `json:"result"`
 	}{}
-	params := &Params{Method:"orders.create", Algo:AlgoTypeMAX, Location:LocationMAX, ApiId:client.apiid, ApiKey:client.apikey}
-	_, err := client.sling.New().Get("").QueryStruct(params).QueryStruct(order).ReceiveSuccess(&stats)
+	params := &Params{Method: "orders.create", Algo: AlgoTypeNone, Location: LocationMAX, ApiId: client.apiid, ApiKey: client.apikey}
+	resp, err := client.receive(ctx, client.sling.New().Get("").QueryStruct(params).QueryStruct(order), &stats)
 	if err != nil {
 		return stats.Result.Success, err
 	}
+	if err = client.checkResult(params.Method, resp, stats.Result.Error); err != nil {
+		return stats.Result.Success, err
+	}
 
 	return stats.Result.Success, nil
 }
 
-func (client *NicehashClient) OrderRefill(algo AlgoType, location Location, order uint, amount float64) (string, error) {
+// FixedPriceQuote is the current price of a fixed order.
+type FixedPriceQuote struct {
+	// FixedMax is the largest speed limit available for a fixed order.
+	FixedMax SpeedLimit
+	// Price is the price of a fixed order of the quoted limit.
+	Price Price
+}
+
+// FixedPriceQuoter quotes fixed orders. The v1 api has no quote method,
+// apiv2.Client implements it with the v2 api and its own credentials.
+type FixedPriceQuoter interface {
+	FixedPriceQuote(ctx context.Context, algo AlgoType, location Location, limit SpeedLimit) (FixedPriceQuote, error)
+}
+
+// SetFixedPriceQuoter sets the quoter of GetFixedPrice.
+func (client *NicehashClient) SetFixedPriceQuoter(quoter FixedPriceQuoter) {
+	client.quoter = quoter
+}
+
+// GetFixedPrice returns the current price of a fixed order of algo at
+// location with the speed limit limit, to be passed to OrderCreate. It
+// needs a FixedPriceQuoter, see WithFixedPriceQuoter.
+func (client *NicehashClient) GetFixedPrice(algo AlgoType, location Location, limit SpeedLimit) (FixedPriceQuote, error) {
+	return client.GetFixedPriceContext(context.Background(), algo, location, limit)
+}
+
+func (client *NicehashClient) GetFixedPriceContext(ctx context.Context, algo AlgoType, location Location, limit SpeedLimit) (FixedPriceQuote, error) {
+	if client.quoter == nil {
+		return FixedPriceQuote{}, errors.New("nicehash: fixed price quote without FixedPriceQuoter")
+	}
+	if limit <= 0 {
+		return FixedPriceQuote{}, errors.New("nicehash: fixed price quote without speed limit")
+	}
+	return client.quoter.FixedPriceQuote(ctx, algo, location, limit)
+}
+
+func (client *NicehashClient) OrderRefill(algo AlgoType, location Location, order uint, amount Amount) (string, error) {
+	return client.OrderRefillContext(context.Background(), algo, location, order, amount)
+}
+
+func (client *NicehashClient) OrderRefillContext(ctx context.Context, algo AlgoType, location Location, order uint, amount Amount) (string, error) {
 	stats := &struct {
 		Result struct {
-			       Success string `json:"success"`
-		       } `json:"result"`
+			Error   string `json:"error"`
+			Success string `json:"success"`
+		} `json:"result"`
 	}{}
-	params := &Params{Method:"orders.refill", Order:order, Algo:algo, Location:location, Amount:amount, ApiId:client.apiid, ApiKey:client.apikey}
-	_, err := client.sling.New().Get("").QueryStruct(params).ReceiveSuccess(&stats)
+	params := &Params{Method: "orders.refill", Order: order, Algo: algo, Location: location, Amount: amount, ApiId: client.apiid, ApiKey: client.apikey}
+	resp, err := client.receive(ctx, client.sling.New().Get("").QueryStruct(params), &stats)
 	if err != nil {
 		return stats.Result.Success, err
 	}
+	if err = client.checkResult(params.Method, resp, stats.Result.Error); err != nil {
+		return stats.Result.Success, err
+	}
 
 	return stats.Result.Success, nil
 }
 
 func (client *NicehashClient) OrderRemove(algo AlgoType, location Location, order uint) (string, error) {
+	return client.OrderRemoveContext(context.Background(), algo, location, order)
+}
+
+func (client *NicehashClient) OrderRemoveContext(ctx context.Context, algo AlgoType, location Location, order uint) (string, error) {
 	stats := &struct {
 		Result struct {
-			       Success string `json:"success"`
-		       } `json:"result"`
+			Error   string `json:"error"`
+			Success string `json:"success"`
+		} `json:"result"`
 	}{}
-	params := &Params{Method:"orders.remove", Order:order, Algo:algo, Location:location, ApiId:client.apiid, ApiKey:client.apikey}
-	_, err := client.sling.New().Get("").QueryStruct(params).ReceiveSuccess(&stats)
+	params := &Params{Method: "orders.remove", Order: order, Algo: algo, Location: location, ApiId: client.apiid, ApiKey: client.apikey}
+	resp, err := client.receive(ctx, client.sling.New().Get("").QueryStruct(params), &stats)
 	if err != nil {
 		return stats.Result.Success, err
 	}
+	if err = client.checkResult(params.Method, resp, stats.Result.Error); err != nil {
+		return stats.Result.Success, err
+	}
 
 	return stats.Result.Success, nil
 }
 
-func (client *NicehashClient) OrderSetPrice(algo AlgoType, location Location, order uint, price float32) (string, error) {
+func (client *NicehashClient) OrderSetPrice(algo AlgoType, location Location, order uint, price Price) (string, error) {
+	return client.OrderSetPriceContext(context.Background(), algo, location, order, price)
+}
+
+func (client *NicehashClient) OrderSetPriceContext(ctx context.Context, algo AlgoType, location Location, order uint, price Price) (string, error) {
 	stats := &struct {
 		Result struct {
-			       Success string `json:"success"`
-		       } `json:"result"`
+			Error   string `json:"error"`
+			Success string `json:"success"`
+		} `json:"result"`
 	}{}
-	params := &Params{Method:"orders.set.price", Algo:algo, Location:location, Order:order, Price:price, ApiId:client.apiid, ApiKey:client.apikey}
-	_, err := client.sling.New().Get("").QueryStruct(params).ReceiveSuccess(&stats)
+	params := &Params{Method: "orders.set.price", Algo: algo, Location: location, Order: order, Price: price, ApiId: client.apiid, ApiKey: client.apikey}
+	resp, err := client.receive(ctx, client.sling.New().Get("").QueryStruct(params), &stats)
 	if err != nil {
 		return stats.Result.Success, err
 	}
+	if err = client.checkResult(params.Method, resp, stats.Result.Error); err != nil {
+		return stats.Result.Success, err
+	}
 
 	return stats.Result.Success, nil
 }
 
 func (client *NicehashClient) OrderSetPriceDecrease(algo AlgoType, location Location, order uint) (string, error) {
+	return client.OrderSetPriceDecreaseContext(context.Background(), algo, location, order)
+}
+
+func (client *NicehashClient) OrderSetPriceDecreaseContext(ctx context.Context, algo AlgoType, location Location, order uint) (string, error) {
 	stats := &struct {
 		Result struct {
-			       Success string `json:"success"`
-		       } `json:"result"`
+			Error   string `json:"error"`
+			Success string `json:"success"`
+		} `json:"result"`
 	}{}
-	params := &Params{Method:"orders.set.price.decrease", Algo:algo, Location:location, Order:order, ApiId:client.apiid, ApiKey:client.apikey}
-	_, err := client.sling.New().Get("").QueryStruct(params).ReceiveSuccess(&stats)
+	params := &Params{Method: "orders.set.price.decrease", Algo: algo, Location: location, Order: order, ApiId: client.apiid, ApiKey: client.apikey}
+	resp, err := client.receive(ctx, client.sling.New().Get("").QueryStruct(params), &stats)
 	if err != nil {
 		return stats.Result.Success, err
 	}
+	if err = client.checkResult(params.Method, resp, stats.Result.Error); err != nil {
+		return stats.Result.Success, err
+	}
 
 	return stats.Result.Success, nil
 }
 
-func (client *NicehashClient) OrderSetLimit(algo AlgoType, location Location, order uint, limit float32) (string, error) {
+func (client *NicehashClient) OrderSetLimit(algo AlgoType, location Location, order uint, limit SpeedLimit) (string, error) {
+	return client.OrderSetLimitContext(context.Background(), algo, location, order, limit)
+}
+
+func (client *NicehashClient) OrderSetLimitContext(ctx context.Context, algo AlgoType, location Location, order uint, limit SpeedLimit) (string, error) {
 	stats := &struct {
 		Result struct {
-			       Success string `json:"success"`
-		       } `json:"result"`
+			Error   string `json:"error"`
+			Success string `json:"success"`
+		} `json:"result"`
 	}{}
-	params := &Params{Method:"orders.set.price.limit", Algo:algo, Location:location, Order:order, Limit:limit, ApiId:client.apiid, ApiKey:client.apikey}
-	_, err := client.sling.New().Get("").QueryStruct(params).ReceiveSuccess(&stats)
+	params := &Params{Method: "orders.set.price.limit", Algo: algo, Location: location, Order: order, Limit: limit, ApiId: client.apiid, ApiKey: client.apikey}
+	resp, err := client.receive(ctx, client.sling.New().Get("").QueryStruct(params), &stats)
 	if err != nil {
 		return stats.Result.Success, err
 	}
+	if err = client.checkResult(params.Method, resp, stats.Result.Error); err != nil {
+		return stats.Result.Success, err
+	}
 
 	return stats.Result.Success, nil
 }
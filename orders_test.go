@@ -1,9 +1,11 @@
 package nicehash
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"testing"
+	"time"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -33,10 +35,10 @@ func TestGetOrders(t *testing.T) {
 		{
 			Type: 0,
 			Id: 5877,
-			Price: 0.0505,
+			Price: 5050000,
 			Algo: 1,
 			Alive: true,
-			LimitSpeed: 1.0,
+			LimitSpeed: 100000000,
 			Workers: 0,
 			AcceptedSpeed: 0.0,
 		},
@@ -88,8 +90,8 @@ func TestGetMyOrders(t *testing.T) {
 	expectedItem := []MyOrders{
 		{
 			Type: 0,
-			BtcAvail: 0.01751439,
-			LimitSpeed: 0.0,
+			BtcAvail: 1751439,
+			LimitSpeed: 0,
 			PoolUser: "worker",
 			PoolPort: 3333,
 			Alive: false,
@@ -98,8 +100,8 @@ func TestGetMyOrders(t *testing.T) {
 			AcceptedSpeed: 0.0,
 			Id: 1879,
 			Algo: 0,
-			Price: 1.0000,
-			BtcPaid: 0.00000000,
+			Price: 100000000,
+			BtcPaid: 0,
 			PoolHost: "testpool.com",
 			End: 1413294447421,
 		},
@@ -121,6 +123,69 @@ func TestGetMyOrders(t *testing.T) {
 	assert.Equal(t, expectedItem, stats)
 }
 
+func TestGetMyOrdersFixed(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	sampleItem := `{
+	   "result":{
+	      "orders":[
+		 {
+		    "type":1,
+		    "btc_avail":"0.004",
+		    "limit_speed":"0.5",
+		    "pool_user":"worker",
+		    "pool_port":3333,
+		    "alive":true,
+		    "workers":2,
+		    "pool_pass":"x",
+		    "accepted_speed":"0.49",
+		    "id":1880,
+		    "algo":1,
+		    "price":"0.0612",
+		    "btc_paid":"0.006",
+		    "pool_host":"testpool.com",
+		    "end":1413294447000
+		 }
+	      ]
+	   },
+	   "method":"orders.get"
+	}`
+
+	expectedItem := []MyOrders{
+		{
+			Type: OrderTypeFixed,
+			BtcAvail: 400000,
+			LimitSpeed: 50000000,
+			PoolUser: "worker",
+			PoolPort: 3333,
+			Alive: true,
+			Workers: 2,
+			PoolPass: "x",
+			AcceptedSpeed: 0.49,
+			Id: 1880,
+			Algo: AlgoTypeSHA256,
+			Price: 6120000,
+			BtcPaid: 600000,
+			PoolHost: "testpool.com",
+			End: 1413294447000,
+		},
+	}
+
+	mux.HandleFunc("/api", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, sampleItem)
+	})
+
+	nicehashClient := NewNicehashClient(httpClient, "", "FAKEID", "FAKEKEY", "")
+	stats, err := nicehashClient.GetMyOrders(AlgoTypeSHA256, 0)
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedItem, stats)
+	assert.True(t, stats[0].IsFixed())
+	assert.Equal(t, time.Date(2014, 10, 14, 13, 47, 27, 0, time.UTC), stats[0].ExpiresAt())
+}
+
 func TestOrderRefill(t *testing.T) {
 	httpClient, mux, server := testServer()
 	defer server.Close()
@@ -140,7 +205,7 @@ func TestOrderRefill(t *testing.T) {
 	})
 
 	nicehashClient := NewNicehashClient(httpClient, "", "FAKEID", "FAKEKEY", "")
-	version, err := nicehashClient.OrderRefill(0, 0, 123, 0.01)
+	version, err := nicehashClient.OrderRefill(0, 0, 123, 1000000)
 
 	assert.Nil(t, err)
 	assert.Equal(t, expectedItem, version)
@@ -189,7 +254,7 @@ func TestOrderSetPrice(t *testing.T) {
 	})
 
 	nicehashClient := NewNicehashClient(httpClient, "", "FAKEID", "FAKEKEY", "")
-	version, err := nicehashClient.OrderSetPrice(0, 0, 123, 2.1)
+	version, err := nicehashClient.OrderSetPrice(0, 0, 123, 210000000)
 
 	assert.Nil(t, err)
 	assert.Equal(t, expectedItem, version)
@@ -238,8 +303,95 @@ func TestOrderSetLimit(t *testing.T) {
 	})
 
 	nicehashClient := NewNicehashClient(httpClient, "", "FAKEID", "FAKEKEY", "")
-	version, err := nicehashClient.OrderSetLimit(0, 0, 123, 1.0)
+	version, err := nicehashClient.OrderSetLimit(0, 0, 123, 100000000)
 
 	assert.Nil(t, err)
 	assert.Equal(t, expectedItem, version)
 }
+
+func TestOrderCreateFixed(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	sampleItem := `{"result":{"success":"Order #123 created."},"method":"orders.create"}`
+
+	expectedItem := "Order #123 created."
+
+	calls := 0
+	mux.HandleFunc("/api", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "orders.create", r.URL.Query().Get("method"))
+		assert.Equal(t, "1", r.URL.Query().Get("type"))
+		assert.Equal(t, "0.0505", r.URL.Query().Get("price"))
+		assert.Equal(t, "0.5", r.URL.Query().Get("limit"))
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, sampleItem)
+	})
+
+	nicehashClient := NewNicehashClient(httpClient, "", "FAKEID", "FAKEKEY", "")
+	order := NewOrder{Algo: AlgoTypeSHA256, Type: OrderTypeFixed, Price: 5050000, Amount: 1000000, PoolHost: "testpool.com", PoolPort: 3333, PoolUser: "worker", PoolPass: "x"}
+	_, err := nicehashClient.OrderCreate(order)
+	assert.NotNil(t, err)
+	assert.Equal(t, 0, calls)
+
+	order.LimitSpeed = 50000000
+	version, err := nicehashClient.OrderCreate(order)
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedItem, version)
+}
+
+func TestOrderCreateStandard(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	sampleItem := `{"result":{"success":"Order #124 created."},"method":"orders.create"}`
+
+	mux.HandleFunc("/api", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "orders.create", r.URL.Query().Get("method"))
+		_, ok := r.URL.Query()["type"]
+		assert.False(t, ok)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, sampleItem)
+	})
+
+	nicehashClient := NewNicehashClient(httpClient, "", "FAKEID", "FAKEKEY", "")
+	order := NewOrder{Algo: AlgoTypeSHA256, Price: 5050000, Amount: 1000000, PoolHost: "testpool.com", PoolPort: 3333, PoolUser: "worker", PoolPass: "x"}
+	success, err := nicehashClient.OrderCreate(order)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "Order #124 created.", success)
+}
+
+func TestMyOrdersIsFixed(t *testing.T) {
+	assert.True(t, MyOrders{Type: OrderTypeFixed}.IsFixed())
+	assert.False(t, MyOrders{Type: OrderTypeStandard}.IsFixed())
+}
+
+type testQuoter struct {
+	limit SpeedLimit
+}
+
+func (q *testQuoter) FixedPriceQuote(ctx context.Context, algo AlgoType, location Location, limit SpeedLimit) (FixedPriceQuote, error) {
+	q.limit = limit
+	return FixedPriceQuote{FixedMax: 1230000000, Price: 6120000}, nil
+}
+
+func TestGetFixedPrice(t *testing.T) {
+	nicehashClient, err := New()
+	assert.Nil(t, err)
+	_, err = nicehashClient.GetFixedPrice(AlgoTypeSHA256, LocationNiceHash, 50000000)
+	assert.NotNil(t, err)
+
+	quoter := &testQuoter{}
+	nicehashClient, err = New(WithFixedPriceQuoter(quoter))
+	assert.Nil(t, err)
+	quote, err := nicehashClient.GetFixedPrice(AlgoTypeSHA256, LocationNiceHash, 50000000)
+	assert.Nil(t, err)
+	assert.Equal(t, FixedPriceQuote{FixedMax: 1230000000, Price: 6120000}, quote)
+	assert.Equal(t, SpeedLimit(50000000), quoter.limit)
+
+	_, err = nicehashClient.GetFixedPrice(AlgoTypeSHA256, LocationNiceHash, 0)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,145 @@
+package nicehash
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RateLimitClass selects the request budget of an api call.
+type RateLimitClass int
+
+const (
+	// RateLimitPublic is the budget of the methods callable without
+	// credentials, eg. stats.global.current or orders.get.
+	RateLimitPublic RateLimitClass = iota
+	// RateLimitPrivate is the budget of the methods which need the api id
+	// and key, eg. balance, orders.create, orders.refill or orders.set.price.
+	RateLimitPrivate
+)
+
+// RateLimit describes a token bucket.
+type RateLimit struct {
+	Rate    float64       // requests per second, zero or less means unlimited
+	Burst   int           // requests allowed at once
+	Backoff time.Duration // pause after a throttling response without Retry-After
+}
+
+var (
+	DefaultPublicRateLimit  = RateLimit{Rate: 2, Burst: 10, Backoff: 30 * time.Second}
+	DefaultPrivateRateLimit = RateLimit{Rate: 1, Burst: 3, Backoff: 30 * time.Second}
+)
+
+// RateLimiter keeps a separate token bucket for public and private api
+// methods. It is safe for concurrent use, so one limiter can be shared by
+// every goroutine (and client) using the same api key.
+type RateLimiter struct {
+	mu      sync.Mutex
+	buckets [2]bucket
+}
+
+type bucket struct {
+	limit  RateLimit
+	tokens float64
+	last   time.Time
+	until  time.Time
+}
+
+// NewRateLimiter returns a limiter with the given public and private budgets.
+func NewRateLimiter(public RateLimit, private RateLimit) *RateLimiter {
+	now := time.Now()
+	l := &RateLimiter{}
+	l.buckets[RateLimitPublic] = bucket{limit: public, tokens: float64(public.Burst), last: now}
+	l.buckets[RateLimitPrivate] = bucket{limit: private, tokens: float64(private.Burst), last: now}
+	return l
+}
+
+// Wait blocks until a request of the class is allowed or ctx is done.
+func (l *RateLimiter) Wait(ctx context.Context, class RateLimitClass) error {
+	l.mu.Lock()
+	wait := l.buckets[class].reserve(time.Now())
+	l.mu.Unlock()
+	if wait <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		l.mu.Lock()
+		l.buckets[class].cancel()
+		l.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// Backoff pauses every request of the class for d. If d is zero or less the
+// Backoff of the class' RateLimit is used.
+func (l *RateLimiter) Backoff(class RateLimitClass, d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	b := &l.buckets[class]
+	if d <= 0 {
+		d = b.limit.Backoff
+	}
+	if until := time.Now().Add(d); until.After(b.until) {
+		b.until = until
+	}
+}
+
+// reserve takes a token and returns how long the caller has to wait for it.
+func (b *bucket) reserve(now time.Time) time.Duration {
+	var wait time.Duration
+	if b.limit.Rate > 0 {
+		b.tokens += now.Sub(b.last).Seconds() * b.limit.Rate
+		if burst := float64(b.limit.Burst); b.tokens > burst {
+			b.tokens = burst
+		}
+		b.last = now
+		b.tokens--
+		if b.tokens < 0 {
+			wait = time.Duration(-b.tokens / b.limit.Rate * float64(time.Second))
+		}
+	}
+	if pause := b.until.Sub(now); pause > wait {
+		wait = pause
+	}
+	return wait
+}
+
+// cancel gives back the token of an abandoned reservation.
+func (b *bucket) cancel() {
+	if b.limit.Rate > 0 {
+		b.tokens++
+	}
+}
+
+// rateLimitClass returns the budget of req: requests carrying the api key
+// are private.
+func rateLimitClass(req *http.Request) RateLimitClass {
+	if req.URL.Query().Get("key") != "" {
+		return RateLimitPrivate
+	}
+	return RateLimitPublic
+}
+
+// retryAfter returns the delay requested by the Retry-After header of resp.
+func retryAfter(resp *http.Response) time.Duration {
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(resp.Header.Get("Retry-After")); err == nil {
+		return time.Until(date)
+	}
+	return 0
+}
+
+// SetRateLimiter replaces the rate limiter of the client. A nil limiter
+// disables client side rate limiting.
+func (client *NicehashClient) SetRateLimiter(limiter *RateLimiter) {
+	client.httpClient.limiter = limiter
+}
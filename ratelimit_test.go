@@ -0,0 +1,66 @@
+package nicehash
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimiterBuckets(t *testing.T) {
+	limiter := NewRateLimiter(RateLimit{Rate: 20, Burst: 1}, RateLimit{})
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		assert.Nil(t, limiter.Wait(context.Background(), RateLimitPublic))
+	}
+	assert.True(t, time.Since(start) >= 90*time.Millisecond)
+
+	start = time.Now()
+	for i := 0; i < 3; i++ {
+		assert.Nil(t, limiter.Wait(context.Background(), RateLimitPrivate))
+	}
+	assert.True(t, time.Since(start) < 50*time.Millisecond)
+}
+
+func TestRateLimiterWaitCancel(t *testing.T) {
+	limiter := NewRateLimiter(RateLimit{Rate: 0.1, Burst: 1}, RateLimit{})
+	assert.Nil(t, limiter.Wait(context.Background(), RateLimitPublic))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, context.DeadlineExceeded, limiter.Wait(ctx, RateLimitPublic))
+}
+
+func TestRateLimiterBackoff(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	calls := 0
+	mux.HandleFunc("/api", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		if calls == 1 {
+			fmt.Fprint(w, `{"result":{"error":"Too many requests."},"method":"balance"}`)
+			return
+		}
+		fmt.Fprint(w, `{"result":{"balance_confirmed":"0.00500000","balance_pending":"0.00000000"},"method":"balance"}`)
+	})
+
+	nicehashClient := NewNicehashClient(httpClient, "", "FAKEID", "FAKEKEY", "")
+	nicehashClient.SetRateLimiter(NewRateLimiter(RateLimit{}, RateLimit{Backoff: 100 * time.Millisecond}))
+
+	_, err := nicehashClient.GetBalance()
+	assert.True(t, IsRateLimited(err))
+
+	start := time.Now()
+	_, err = nicehashClient.GetBalance()
+	assert.Nil(t, err)
+	assert.True(t, time.Since(start) >= 90*time.Millisecond)
+
+	start = time.Now()
+	_, err = nicehashClient.GetOrders(0, 0)
+	assert.True(t, time.Since(start) < 50*time.Millisecond)
+}
@@ -0,0 +1,97 @@
+package nicehash
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// RetryPolicy retries api calls failed with a transient error. Only the
+// read-only methods are retried unless RetryMutators is set.
+type RetryPolicy struct {
+	MaxAttempts int           // attempts including the first one
+	BaseDelay   time.Duration // delay before the second attempt, doubled for every further one
+	MaxDelay    time.Duration // upper limit of the delay, zero means no limit
+
+	// Retryable reports whether the attempt which returned resp or err
+	// should be retried. Nil means DefaultRetryable.
+	Retryable func(resp *http.Response, err error) bool
+
+	// RetryMutators enables retrying methods which are not idempotent,
+	// eg. orders.create or orders.refill. A retried mutator may be
+	// executed twice by the server.
+	RetryMutators bool
+}
+
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   500 * time.Millisecond,
+	MaxDelay:    10 * time.Second,
+}
+
+// idempotentMethods are the api methods which are safe to send again.
+// The version call has no method.
+var idempotentMethods = map[string]bool{
+	"":                       true,
+	"balance":                true,
+	"buy.info":               true,
+	"multialgo.info":         true,
+	"simplemultialgo.info":   true,
+	"orders.get":             true,
+	"stats.global.current":   true,
+	"stats.global.24h":       true,
+	"stats.provider":         true,
+	"stats.provider.ex":      true,
+	"stats.provider.workers": true,
+}
+
+// DefaultRetryable retries connection errors, timeouts and 5xx responses.
+// Canceled or expired contexts are never retried.
+func DefaultRetryable(resp *http.Response, err error) bool {
+	if err != nil {
+		return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+	return resp.StatusCode >= 500
+}
+
+// SetRetryPolicy sets the retry policy of the client. A nil policy
+// disables retrying, which is the default.
+func (client *NicehashClient) SetRetryPolicy(policy *RetryPolicy) {
+	client.httpClient.retry = policy
+}
+
+// attempts returns how many times a call of the api method may be sent.
+func (p *RetryPolicy) attempts(method string) int {
+	if p == nil || p.MaxAttempts < 1 || !(p.RetryMutators || idempotentMethods[method]) {
+		return 1
+	}
+	return p.MaxAttempts
+}
+
+func (p *RetryPolicy) retryable(resp *http.Response, err error) bool {
+	if p.Retryable != nil {
+		return p.Retryable(resp, err)
+	}
+	return DefaultRetryable(resp, err)
+}
+
+// wait sleeps before the next attempt with an exponential, jittered delay.
+func (p *RetryPolicy) wait(ctx context.Context, attempt int) error {
+	delay := p.BaseDelay << uint(attempt-1)
+	if delay <= 0 || (p.MaxDelay > 0 && delay > p.MaxDelay) {
+		delay = p.MaxDelay
+	}
+	if delay > 0 {
+		delay = delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
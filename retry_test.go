@@ -0,0 +1,69 @@
+package nicehash
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+	"github.com/stretchr/testify/assert"
+)
+
+func testRetryServer(failures int) (*NicehashClient, *int, func()) {
+	httpClient, mux, server := testServer()
+
+	calls := 0
+	mux.HandleFunc("/api", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls <= failures {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"result":{"stats":[],"success":"ok"},"method":"`+r.URL.Query().Get("method")+`"}`)
+	})
+
+	nicehashClient := NewNicehashClient(httpClient, "", "FAKEID", "FAKEKEY", "")
+	nicehashClient.SetRateLimiter(nil)
+	return nicehashClient, &calls, server.Close
+}
+
+func TestRetryReadOnly(t *testing.T) {
+	nicehashClient, calls, closer := testRetryServer(2)
+	defer closer()
+
+	nicehashClient.SetRetryPolicy(&RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond})
+	stats, err := nicehashClient.GetStatsGlobalCurrent()
+
+	assert.Nil(t, err)
+	assert.Equal(t, []GlobalStats{}, stats)
+	assert.Equal(t, 3, *calls)
+}
+
+func TestRetryGiveUp(t *testing.T) {
+	nicehashClient, calls, closer := testRetryServer(5)
+	defer closer()
+
+	nicehashClient.SetRetryPolicy(&RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond})
+	_, err := nicehashClient.GetStatsGlobalCurrent()
+
+	assert.IsType(t, &APIError{}, err)
+	assert.Equal(t, http.StatusServiceUnavailable, err.(*APIError).StatusCode)
+	assert.Equal(t, 3, *calls)
+}
+
+func TestRetryMutators(t *testing.T) {
+	nicehashClient, calls, closer := testRetryServer(1)
+	defer closer()
+
+	nicehashClient.SetRetryPolicy(&RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond})
+	_, err := nicehashClient.OrderRefill(0, 0, 123, 1000000)
+	assert.NotNil(t, err)
+	assert.Equal(t, 1, *calls)
+
+	*calls = 0
+	nicehashClient.SetRetryPolicy(&RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, RetryMutators: true})
+	success, err := nicehashClient.OrderRefill(0, 0, 123, 1000000)
+	assert.Nil(t, err)
+	assert.Equal(t, "ok", success)
+	assert.Equal(t, 2, *calls)
+}
@@ -1,8 +1,10 @@
 package nicehash
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
-	"errors"
+	"fmt"
 	"strconv"
 	"time"
 )
@@ -11,66 +13,70 @@ type GlobalStats struct {
 	Algo                  AlgoType `json:"algo"`
 	ProfitabilityAboveBtc float32  `json:"profitability_above_btc,string"`
 	ProfitabilityAboveLtc float32  `json:"profitability_above_ltc,string"`
-	Price                 float64  `json:"price,string"`
-	ProfitabilityBtc      float32  `json:"profitability_btc,string"`
+	Price                 Price    `json:"price"`
+	ProfitabilityBtc      Price    `json:"profitability_btc"`
 	ProfitabilityLtc      float32  `json:"profitability_ltc,string"`
 	Speed                 float64  `json:"speed,string"`
 }
 
 func (client *NicehashClient) GetStatsGlobalCurrent() ([]GlobalStats, error) {
+	return client.GetStatsGlobalCurrentContext(context.Background())
+}
+
+func (client *NicehashClient) GetStatsGlobalCurrentContext(ctx context.Context) ([]GlobalStats, error) {
 	stats := &struct {
 		Result struct {
 			Error string        `json:"error"`
 			Stats []GlobalStats `json:"stats"`
 		} `json:"result"`
 	}{}
-	params := &Params{Method: "stats.global.current", Algo: AlgoTypeMAX, Location: LocationMAX}
-	resp, err := client.sling.New().Get("").QueryStruct(params).ReceiveSuccess(&stats)
+	params := &Params{Method: "stats.global.current", Algo: AlgoTypeNone, Location: LocationMAX}
+	resp, err := client.receive(ctx, client.sling.New().Get("").QueryStruct(params), &stats)
 	if err != nil {
 		return nil, err
 	}
-	if code := resp.StatusCode; code < 200 || 299 < code {
-		return nil, errors.New("Http response: " + resp.Status)
-	}
-	if stats.Result.Error != "" {
-		return nil, errors.New(stats.Result.Error)
+	if err = client.checkResult(params.Method, resp, stats.Result.Error); err != nil {
+		return nil, err
 	}
 	return stats.Result.Stats, nil
 }
 
 func (client *NicehashClient) GetStatsGlobalDay() ([]GlobalStats, error) {
+	return client.GetStatsGlobalDayContext(context.Background())
+}
+
+func (client *NicehashClient) GetStatsGlobalDayContext(ctx context.Context) ([]GlobalStats, error) {
 	stats := &struct {
 		Result struct {
 			Error string        `json:"error"`
 			Stats []GlobalStats `json:"stats"`
 		} `json:"result"`
 	}{}
-	params := &Params{Method: "stats.global.24h", Algo: AlgoTypeMAX, Location: LocationMAX}
-	resp, err := client.sling.New().Get("").QueryStruct(params).ReceiveSuccess(&stats)
+	params := &Params{Method: "stats.global.24h", Algo: AlgoTypeNone, Location: LocationMAX}
+	resp, err := client.receive(ctx, client.sling.New().Get("").QueryStruct(params), &stats)
 	if err != nil {
 		return nil, err
 	}
-	if code := resp.StatusCode; code < 200 || 299 < code {
-		return nil, errors.New("Http response: " + resp.Status)
-	}
-	if stats.Result.Error != "" {
-		return nil, errors.New(stats.Result.Error)
+	if err = client.checkResult(params.Method, resp, stats.Result.Error); err != nil {
+		return nil, err
 	}
 	return stats.Result.Stats, nil
 }
 
 type ProviderStats struct {
 	Algo          AlgoType `json:"algo"`
-	Balance       float64  `json:"balance,string"`
+	Balance       Amount   `json:"balance"`
 	AcceptedSpeed float64  `json:"accepted_speed,string"`
 	RejectedSpeed float64  `json:"rejected_speed,string"`
 }
 
 type ProviderPayments struct {
-	Amount float64   `json:"amount,string"`
-	Fee    float64   `json:"fee,string"`
-	TxID   string    `json:"TXID"`
-	Time   time.Time `json:"time"`
+	Amount Amount `json:"amount"`
+	Fee    Amount `json:"fee"`
+	TxID   string `json:"TXID"`
+	// Time is in UTC, the server sends it in its own time zone, see
+	// SetServerLocation.
+	Time time.Time `json:"time"`
 }
 
 func (t *ProviderPayments) UnmarshalJSON(data []byte) error {
@@ -82,14 +88,19 @@ func (t *ProviderPayments) UnmarshalJSON(data []byte) error {
 	}{
 		Alias: (*Alias)(t),
 	}
-	if err = json.Unmarshal(data, &aux); err != nil {
+	if err = json.Unmarshal(data, aux); err != nil {
 		return err
 	}
-	t.Time, err = time.Parse("2006-01-02 15:04:05", aux.Time)
+	// read as UTC, the client moves it to the server location
+	t.Time, err = time.Parse(serverTimeLayout, aux.Time)
 	return err
 }
 
 func (client *NicehashClient) GetStatsProvider(addr string) ([]ProviderStats, []ProviderPayments, error) {
+	return client.GetStatsProviderContext(context.Background(), addr)
+}
+
+func (client *NicehashClient) GetStatsProviderContext(ctx context.Context, addr string) ([]ProviderStats, []ProviderPayments, error) {
 	stats := &struct {
 		Result struct {
 			Error    string             `json:"error"`
@@ -97,16 +108,16 @@ func (client *NicehashClient) GetStatsProvider(addr string) ([]ProviderStats, []
 			Payments []ProviderPayments `json:"payments"`
 		} `json:"result"`
 	}{}
-	params := &Params{Method: "stats.provider", Algo: AlgoTypeMAX, Location: LocationMAX, Addr: addr}
-	resp, err := client.sling.New().Get("").QueryStruct(params).ReceiveSuccess(&stats)
+	params := &Params{Method: "stats.provider", Algo: AlgoTypeNone, Location: LocationMAX, Addr: addr}
+	resp, err := client.receive(ctx, client.sling.New().Get("").QueryStruct(params), &stats)
 	if err != nil {
 		return nil, nil, err
 	}
-	if code := resp.StatusCode; code < 200 || 299 < code {
-		return nil, nil, errors.New("Http response: " + resp.Status)
+	if err = client.checkResult(params.Method, resp, stats.Result.Error); err != nil {
+		return nil, nil, err
 	}
-	if stats.Result.Error != "" {
-		return nil, nil, errors.New(stats.Result.Error)
+	for i := range stats.Result.Payments {
+		stats.Result.Payments[i].Time = inLocation(stats.Result.Payments[i].Time, client.ServerLocation())
 	}
 	return stats.Result.Stats, stats.Result.Payments, nil
 }
@@ -115,97 +126,104 @@ type ProviderExStats struct {
 	Algo          AlgoType `json:"algo"`
 	Suffix        string   `json:"suffix"`
 	Name          string   `json:"name"`
-	Profitability float64  `json:"profitability,string"`
-	Unpaid        float64  `json:"balance,string"`
+	Profitability Price    `json:"profitability"`
+	Unpaid        Amount   `json:"balance"`
 	AcceptedSpeed float64  `json:"accepted_speed,string"`
 	RejectedSpeed float64  `json:"rejected_speed,string"`
 }
 
 func (t *ProviderExStats) UnmarshalJSON(data []byte) error {
+	if jsonType(data) == "null" {
+		return nil
+	}
 	var err error
 	type Alias ProviderExStats
 	aux := &struct {
-		Data []interface{} `json:"data"`
+		Data json.RawMessage `json:"data"`
 		*Alias
 	}{
 		Alias: (*Alias)(t),
 	}
-	if err = json.Unmarshal(data, &aux); err != nil {
+	if err = json.Unmarshal(data, aux); err != nil {
 		return err
 	}
-	speeds := aux.Data[0].(map[string]interface{})
-	if val, ok := speeds["a"]; ok {
-		t.AcceptedSpeed, err = strconv.ParseFloat(val.(string), 64)
-		if err != nil {
-			return err
-		}
+	items, err := decodeArray(aux.Data, "ProviderExStats.data", 2)
+	if err != nil {
+		return err
 	}
-	if val, ok := speeds["rs"]; ok {
-		t.RejectedSpeed, err = strconv.ParseFloat(val.(string), 64)
-		if err != nil {
-			return err
-		}
+	if t.AcceptedSpeed, t.RejectedSpeed, err = decodeSpeeds(items[0], "ProviderExStats.data[0]"); err != nil {
+		return err
 	}
-	t.Unpaid, err = strconv.ParseFloat(aux.Data[1].(string), 64)
+	t.Unpaid, err = decodeAmount(items[1], "ProviderExStats.data[1]")
 	return err
 }
 
 type ProviderExHistory struct {
-	Algo AlgoType                            `json:"algo"`
-	Data map[time.Time]ProviderExHistoryItem `json:"data"`
+	Algo AlgoType      `json:"algo"`
+	Data HistorySeries `json:"data"`
 }
 
 type ProviderExHistoryItem struct {
-	Unpaid        float64 `json:"balance,string"`
-	AcceptedSpeed float64 `json:"accepted_speed,string"`
-	RejectedSpeed float64 `json:"rejected_speed,string"`
+	// Time is the start of the 5 minutes slot in UTC.
+	Time          time.Time `json:"time"`
+	Unpaid        Amount    `json:"balance"`
+	AcceptedSpeed float64   `json:"accepted_speed,string"`
+	RejectedSpeed float64   `json:"rejected_speed,string"`
 }
 
 func (t *ProviderExHistory) UnmarshalJSON(data []byte) error {
+	if jsonType(data) == "null" {
+		return nil
+	}
 	var err error
 	type Alias ProviderExHistory
 	aux := &struct {
-		Data [][]interface{} `json:"data"`
+		Data json.RawMessage `json:"data"`
 		*Alias
 	}{
 		Alias: (*Alias)(t),
 	}
-	if err = json.Unmarshal(data, &aux); err != nil {
+	if err = json.Unmarshal(data, aux); err != nil {
 		return err
 	}
-	t.Data = make(map[time.Time]ProviderExHistoryItem)
-	for _, d := range aux.Data {
+	t.Data = nil
+	if aux.Data == nil {
+		return nil
+	}
+	points, err := decodeArray(aux.Data, "ProviderExHistory.data", 0)
+	if err != nil {
+		return err
+	}
+	for i, point := range points {
 		var item ProviderExHistoryItem
-		date := time.Unix(int64(d[0].(float64))*300, 0)
-		speeds := d[1].(map[string]interface{})
-		if val, ok := speeds["a"]; ok {
-			item.AcceptedSpeed, err = strconv.ParseFloat(val.(string), 64)
-			if err != nil {
-				return err
-			}
-		}
-		if val, ok := speeds["rs"]; ok {
-			item.RejectedSpeed, err = strconv.ParseFloat(val.(string), 64)
-			if err != nil {
-				return err
-			}
+		path := fmt.Sprintf("ProviderExHistory.data[%d]", i)
+		d, err := decodeArray(point, path, 3)
+		if err != nil {
+			return err
 		}
-		item.Unpaid, err = strconv.ParseFloat(d[2].(string), 64)
+		slot, err := decodeNumber(d[0], path+"[0]")
 		if err != nil {
 			return err
 		}
-		if item.AcceptedSpeed > 0 || item.RejectedSpeed > 0 {
-			t.Data[date] = item
+		item.Time = unixTime(int64(slot) * 300)
+		if item.AcceptedSpeed, item.RejectedSpeed, err = decodeSpeeds(d[1], path+"[1]"); err != nil {
+			return err
+		}
+		if item.Unpaid, err = decodeAmount(d[2], path+"[2]"); err != nil {
+			return err
 		}
+		t.Data = append(t.Data, item)
 	}
+	t.Data.sort()
 	return nil
 }
 
 type ProviderExPayments struct {
-	Amount float64   `json:"amount,string"`
-	Fee    float64   `json:"fee,string"`
-	TxID   string    `json:"TXID"`
-	Time   time.Time `json:"time"`
+	Amount Amount `json:"amount"`
+	Fee    Amount `json:"fee"`
+	TxID   string `json:"TXID"`
+	// Time is in UTC.
+	Time time.Time `json:"time"`
 }
 
 func (t *ProviderExPayments) UnmarshalJSON(data []byte) error {
@@ -217,10 +235,10 @@ func (t *ProviderExPayments) UnmarshalJSON(data []byte) error {
 	}{
 		Alias: (*Alias)(t),
 	}
-	if err = json.Unmarshal(data, &aux); err != nil {
+	if err = json.Unmarshal(data, aux); err != nil {
 		return err
 	}
-	t.Time = time.Unix(aux.Time, 0)
+	t.Time = unixTime(aux.Time)
 	return err
 }
 
@@ -232,24 +250,41 @@ type StatsProviderEx struct {
 }
 
 func (client *NicehashClient) GetStatsProviderEx(addr string) (StatsProviderEx, error) {
+	return client.GetStatsProviderExContext(context.Background(), addr)
+}
+
+func (client *NicehashClient) GetStatsProviderExContext(ctx context.Context, addr string) (StatsProviderEx, error) {
+	return client.GetStatsProviderExSinceContext(ctx, addr, time.Time{})
+}
+
+// GetStatsProviderExSince returns the stats of addr with the history from
+// the time from. The server may return a shorter history than asked, see
+// ProviderExHistoryIterator to walk back over longer periods. The zero
+// from asks for the default window of the server.
+func (client *NicehashClient) GetStatsProviderExSince(addr string, from time.Time) (StatsProviderEx, error) {
+	return client.GetStatsProviderExSinceContext(context.Background(), addr, from)
+}
+
+func (client *NicehashClient) GetStatsProviderExSinceContext(ctx context.Context, addr string, from time.Time) (StatsProviderEx, error) {
 	stats := &struct {
 		Result StatsProviderEx `json:"result"`
 	}{}
-	params := &Params{Method: "stats.provider.ex", Algo: AlgoTypeMAX, Location: LocationMAX, Addr: addr}
-	resp, err := client.sling.New().Get("").QueryStruct(params).ReceiveSuccess(&stats)
+	params := &Params{Method: "stats.provider.ex", Algo: AlgoTypeNone, Location: LocationMAX, Addr: addr}
+	if !from.IsZero() {
+		params.From = from.Unix()
+	}
+	resp, err := client.receive(ctx, client.sling.New().Get("").QueryStruct(params), &stats)
 	if err != nil {
 		return StatsProviderEx{}, err
 	}
-	if code := resp.StatusCode; code < 200 || 299 < code {
-		return StatsProviderEx{}, errors.New("Http response: " + resp.Status)
-	}
-	if stats.Result.Error != "" {
-		return StatsProviderEx{}, errors.New(stats.Result.Error)
+	if err = client.checkResult(params.Method, resp, stats.Result.Error); err != nil {
+		return StatsProviderEx{}, err
 	}
 	return stats.Result, nil
 }
 
 type ProviderWorker struct {
+	Algo          AlgoType `json:"-"`
 	Name          string   `json:"name"`
 	AcceptedSpeed float64  `json:"accepted_speed,string"`
 	RejectedSpeed float64  `json:"rejected_speed,string"`
@@ -260,36 +295,45 @@ type ProviderWorker struct {
 }
 
 func (t *ProviderWorker) UnmarshalJSON(data []byte) error {
-	var err error
-	var aux []interface{}
-	if err = json.Unmarshal(data, &aux); err != nil {
+	if jsonType(data) == "null" {
+		return nil
+	}
+	aux, err := decodeArray(data, "ProviderWorker", 6)
+	if err != nil {
 		return err
 	}
-	t.Name = aux[0].(string)
-	speeds := aux[1].(map[string]interface{})
-	if val, ok := speeds["a"]; ok {
-		t.AcceptedSpeed, err = strconv.ParseFloat(val.(string), 64)
-		if err != nil {
-			return err
-		}
+	if t.Name, err = decodeString(aux[0], "ProviderWorker[0]"); err != nil {
+		return err
 	}
-	if val, ok := speeds["rs"]; ok {
-		t.RejectedSpeed, err = strconv.ParseFloat(val.(string), 64)
-		if err != nil {
-			return err
-		}
+	if t.AcceptedSpeed, t.RejectedSpeed, err = decodeSpeeds(aux[1], "ProviderWorker[1]"); err != nil {
+		return err
 	}
-	t.Connected = uint64(aux[2].(float64))
-	t.XnSubEnabled = aux[3].(float64) == 1
-	t.Difficulty, err = strconv.ParseFloat(aux[4].(string), 64)
+	connected, err := decodeNumber(aux[2], "ProviderWorker[2]")
 	if err != nil {
 		return err
 	}
-	t.Location = Location(uint64(aux[5].(float64)))
+	t.Connected = uint64(connected)
+	xnsub, err := decodeNumber(aux[3], "ProviderWorker[3]")
+	if err != nil {
+		return err
+	}
+	t.XnSubEnabled = xnsub == 1
+	if t.Difficulty, err = decodeFloat(aux[4], "ProviderWorker[4]"); err != nil {
+		return err
+	}
+	location, err := decodeNumber(aux[5], "ProviderWorker[5]")
+	if err != nil {
+		return err
+	}
+	t.Location = Location(location)
 	return nil
 }
 
 func (client *NicehashClient) GetStatsProviderWorkers(addr string, algo AlgoType) ([]ProviderWorker, error) {
+	return client.GetStatsProviderWorkersContext(context.Background(), addr, algo)
+}
+
+func (client *NicehashClient) GetStatsProviderWorkersContext(ctx context.Context, addr string, algo AlgoType) ([]ProviderWorker, error) {
 	stats := &struct {
 		Result struct {
 			Error   string           `json:"error"`
@@ -299,12 +343,120 @@ func (client *NicehashClient) GetStatsProviderWorkers(addr string, algo AlgoType
 		} `json:"result"`
 	}{}
 	params := &Params{Method: "stats.provider.workers", Algo: algo, Location: LocationMAX, Addr: addr}
-	_, err := client.sling.New().Get("").QueryStruct(params).ReceiveSuccess(&stats)
+	resp, err := client.receive(ctx, client.sling.New().Get("").QueryStruct(params), &stats)
 	if err != nil {
 		return nil, err
 	}
-	if stats.Result.Error != "" {
-		return nil, errors.New(stats.Result.Error)
+	if err = client.checkResult(params.Method, resp, stats.Result.Error); err != nil {
+		return nil, err
+	}
+	for i := range stats.Result.Workers {
+		stats.Result.Workers[i].Algo = stats.Result.Algo
 	}
 	return stats.Result.Workers, nil
 }
+
+// decodeArray decodes a json array of at least min elements.
+func decodeArray(data json.RawMessage, path string, min int) ([]json.RawMessage, error) {
+	var items []json.RawMessage
+	if jsonType(data) != "array" || json.Unmarshal(data, &items) != nil {
+		return nil, decodeError(path, "array", data)
+	}
+	if len(items) < min {
+		return nil, decodeError(path, fmt.Sprintf("array of %d elements", min), data)
+	}
+	return items, nil
+}
+
+func decodeString(data json.RawMessage, path string) (string, error) {
+	var value string
+	if jsonType(data) != "string" || json.Unmarshal(data, &value) != nil {
+		return "", decodeError(path, "string", data)
+	}
+	return value, nil
+}
+
+// decodeNumber decodes a json number.
+func decodeNumber(data json.RawMessage, path string) (float64, error) {
+	var value float64
+	if jsonType(data) != "number" || json.Unmarshal(data, &value) != nil {
+		return 0, decodeError(path, "number", data)
+	}
+	return value, nil
+}
+
+// decodeFloat decodes a number sent as a json string, or as a number.
+func decodeFloat(data json.RawMessage, path string) (float64, error) {
+	if jsonType(data) == "number" {
+		return decodeNumber(data, path)
+	}
+	text, err := decodeString(data, path)
+	if err != nil {
+		return 0, decodeError(path, "number string", data)
+	}
+	value, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return 0, decodeError(path, "number string", data)
+	}
+	return value, nil
+}
+
+func decodeAmount(data json.RawMessage, path string) (Amount, error) {
+	var value Amount
+	if t := jsonType(data); (t != "string" && t != "number") || json.Unmarshal(data, &value) != nil {
+		return 0, decodeError(path, "amount", data)
+	}
+	return value, nil
+}
+
+// decodeSpeeds decodes the {"a": accepted, "rs": rejected} speeds, the
+// missing speeds are zero.
+func decodeSpeeds(data json.RawMessage, path string) (accepted float64, rejected float64, err error) {
+	var speeds map[string]json.RawMessage
+	if jsonType(data) != "object" || json.Unmarshal(data, &speeds) != nil {
+		return 0, 0, decodeError(path, "object", data)
+	}
+	if val, ok := speeds["a"]; ok {
+		if accepted, err = decodeFloat(val, path+".a"); err != nil {
+			return 0, 0, err
+		}
+	}
+	if val, ok := speeds["rs"]; ok {
+		if rejected, err = decodeFloat(val, path+".rs"); err != nil {
+			return 0, 0, err
+		}
+	}
+	return accepted, rejected, nil
+}
+
+// jsonType returns the type of a json value from its first byte.
+func jsonType(data json.RawMessage) string {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return "nothing"
+	}
+	switch data[0] {
+	case '"':
+		return "string"
+	case '{':
+		return "object"
+	case '[':
+		return "array"
+	case 't', 'f':
+		return "bool"
+	case 'n':
+		return "null"
+	}
+	return "number"
+}
+
+func decodeError(path string, expected string, data json.RawMessage) *DecodeError {
+	actual := string(bytes.TrimSpace(data))
+	if len(actual) > 40 {
+		actual = actual[:37] + "..."
+	}
+	if actual == "" {
+		actual = "nothing"
+	}
+	return &DecodeError{Path: path, Expected: expected, Actual: actual}
+}
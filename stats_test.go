@@ -1,6 +1,8 @@
 package nicehash
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"testing"
@@ -36,14 +38,14 @@ func TestGetStatsGlobalCurrent(t *testing.T) {
 	expectedItem := []GlobalStats{
 		{
 			ProfitabilityAboveLtc: 8.27,
-			Price: 0.1683,
+			Price: 16830000,
 			ProfitabilityLtc: 0.1554,
 			Algo:0,
 			Speed: 27.0678,
 		},
 		{
-			Price: 0.0117,
-			ProfitabilityBtc: 0.0114,
+			Price: 1170000,
+			ProfitabilityBtc: 1140000,
 			ProfitabilityAboveBtc: 2.39,
 			Algo:1,
 			Speed: 1597723.0669,
@@ -94,14 +96,14 @@ func TestGetStatsGlobalDay(t *testing.T) {
 	expectedItem := []GlobalStats{
 		{
 			ProfitabilityAboveLtc: 8.27,
-			Price: 0.1683,
+			Price: 16830000,
 			ProfitabilityLtc: 0.1554,
 			Algo:0,
 			Speed: 27.0678,
 		},
 		{
-			Price: 0.0117,
-			ProfitabilityBtc: 0.0114,
+			Price: 1170000,
+			ProfitabilityBtc: 1140000,
 			ProfitabilityAboveBtc: 2.39,
 			Algo:1,
 			Speed: 1597723.0669,
@@ -121,4 +123,83 @@ func TestGetStatsGlobalDay(t *testing.T) {
 
 	assert.Nil(t, err)
 	assert.Equal(t, expectedItem, stats)
-}
\ No newline at end of file
+}
+func TestProviderWorkerUnmarshal(t *testing.T) {
+	var worker ProviderWorker
+	err := json.Unmarshal([]byte(`["rig1",{"a":"12.5","rs":"0.25"},120,1,"1024",1]`), &worker)
+	assert.Nil(t, err)
+	assert.Equal(t, ProviderWorker{Name: "rig1", AcceptedSpeed: 12.5, RejectedSpeed: 0.25, Connected: 120, XnSubEnabled: true, Difficulty: 1024, Location: LocationWestHash}, worker)
+
+	invalid := map[string]DecodeError{
+		`"rig1"`:                             {Path: "ProviderWorker", Expected: "array", Actual: `"rig1"`},
+		`["rig1",{}]`:                        {Path: "ProviderWorker", Expected: "array of 6 elements", Actual: `["rig1",{}]`},
+		`[1,{},120,1,"1024",1]`:              {Path: "ProviderWorker[0]", Expected: "string", Actual: "1"},
+		`["rig1",{"a":null},120,1,"1024",1]`: {Path: "ProviderWorker[1].a", Expected: "number string", Actual: "null"},
+		`["rig1",{},120,1,"abc",1]`:          {Path: "ProviderWorker[4]", Expected: "number string", Actual: `"abc"`},
+		`["rig1",{},120,1,"1024","1"]`:       {Path: "ProviderWorker[5]", Expected: "number", Actual: `"1"`},
+	}
+	for data, expected := range invalid {
+		err := json.Unmarshal([]byte(data), &worker)
+		var decodeErr *DecodeError
+		if assert.True(t, errors.As(err, &decodeErr), data) {
+			assert.Equal(t, expected, *decodeErr, data)
+		}
+	}
+}
+
+func TestProviderExUnmarshal(t *testing.T) {
+	var stats ProviderExStats
+	assert.Nil(t, json.Unmarshal([]byte(`{"algo":20,"data":[{"a":"150.5"},"0.00012345"]}`), &stats))
+	assert.Equal(t, ProviderExStats{Algo: AlgoTypeDaggerHashimoto, AcceptedSpeed: 150.5, Unpaid: 12345}, stats)
+
+	err := json.Unmarshal([]byte(`{"algo":20,"data":[{"a":"150.5"}]}`), &stats)
+	assert.IsType(t, &DecodeError{}, err)
+	err = json.Unmarshal([]byte(`{"algo":20,"data":[null,"0.1"]}`), &stats)
+	assert.Equal(t, &DecodeError{Path: "ProviderExStats.data[0]", Expected: "object", Actual: "null"}, err)
+
+	var history ProviderExHistory
+	assert.Nil(t, json.Unmarshal([]byte(`{"algo":20,"data":[[5000000,{"a":"1"},"0.1"]]}`), &history))
+	assert.Len(t, history.Data, 1)
+
+	err = json.Unmarshal([]byte(`{"algo":20,"data":[[5000000,{"a":"1"},"0.1"],[5000001,{"a":"1"}]]}`), &history)
+	assert.Equal(t, &DecodeError{Path: "ProviderExHistory.data[1]", Expected: "array of 3 elements", Actual: `[5000001,{"a":"1"}]`}, err)
+	err = json.Unmarshal([]byte(`{"algo":20,"data":[["5000000",{"a":"1"},"0.1"]]}`), &history)
+	assert.Equal(t, &DecodeError{Path: "ProviderExHistory.data[0][0]", Expected: "number", Actual: `"5000000"`}, err)
+}
+
+func FuzzProviderWorker(f *testing.F) {
+	f.Add([]byte(`["rig1",{"a":"12.5","rs":"0.25"},120,1,"1024",1]`))
+	f.Add([]byte(`["rig1",{},120,1,"1024"]`))
+	f.Add([]byte(`[null,null,null,null,null,null]`))
+	f.Add([]byte(`[1,2,3,4,5,6]`))
+	f.Add([]byte(`{}`))
+	f.Add([]byte(`null`))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var worker ProviderWorker
+		json.Unmarshal(data, &worker)
+	})
+}
+
+func FuzzProviderExStats(f *testing.F) {
+	f.Add([]byte(`{"algo":20,"data":[{"a":"150.5","rs":"1"},"0.00012345"]}`))
+	f.Add([]byte(`{"algo":20,"data":[]}`))
+	f.Add([]byte(`{"data":[[],{}]}`))
+	f.Add([]byte(`{"data":null}`))
+	f.Add([]byte(`null`))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var stats ProviderExStats
+		json.Unmarshal(data, &stats)
+	})
+}
+
+func FuzzProviderExHistory(f *testing.F) {
+	f.Add([]byte(`{"algo":20,"data":[[5000000,{"a":"1","rs":"0.1"},"0.1"]]}`))
+	f.Add([]byte(`{"algo":20,"data":[[5000000]]}`))
+	f.Add([]byte(`{"data":[null,[1e300,{"a":1},2]]}`))
+	f.Add([]byte(`{"data":{}}`))
+	f.Add([]byte(`null`))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var history ProviderExHistory
+		json.Unmarshal(data, &history)
+	})
+}
@@ -0,0 +1,37 @@
+package nicehash
+
+import "time"
+
+// The times returned by the client are in UTC. The api sends most of them
+// as unix timestamps, the payment times of stats.provider are the wall
+// clock of the server without zone, they are read in the server location,
+// see SetServerLocation.
+
+const serverTimeLayout = "2006-01-02 15:04:05"
+
+func unixTime(sec int64) time.Time {
+	return time.Unix(sec, 0).UTC()
+}
+
+func unixMilliTime(msec int64) time.Time {
+	return time.UnixMilli(msec).UTC()
+}
+
+// inLocation returns the wall clock of t read in loc, in UTC.
+func inLocation(t time.Time, loc *time.Location) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc).UTC()
+}
+
+// SetServerLocation sets the time zone of the server, used to read the
+// payment times of GetStatsProvider. Nil means UTC, the default.
+func (client *NicehashClient) SetServerLocation(loc *time.Location) {
+	client.location = loc
+}
+
+// ServerLocation returns the time zone of the server.
+func (client *NicehashClient) ServerLocation() *time.Location {
+	if client.location == nil {
+		return time.UTC
+	}
+	return client.location
+}
@@ -0,0 +1,45 @@
+package nicehash
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServerLocation(t *testing.T) {
+	httpClient, mux, server := testServer()
+	defer server.Close()
+
+	mux.HandleFunc("/api", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"result":{"stats":[],"payments":[{"amount":"0.001","fee":"0.00002","TXID":"abc","time":"2017-01-02 10:00:00"}]},"method":"stats.provider"}`)
+	})
+
+	nicehashClient := NewNicehashClient(httpClient, "", "FAKEID", "FAKEKEY", "")
+	assert.Equal(t, time.UTC, nicehashClient.ServerLocation())
+	_, payments, err := nicehashClient.GetStatsProvider("FAKEADDR")
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2017, 1, 2, 10, 0, 0, 0, time.UTC), payments[0].Time)
+
+	cet := time.FixedZone("CET", 3600)
+	nicehashClient, err = New(WithHTTPClient(httpClient), WithServerLocation(cet))
+	assert.Nil(t, err)
+	_, payments, err = nicehashClient.GetStatsProvider("FAKEADDR")
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2017, 1, 2, 9, 0, 0, 0, time.UTC), payments[0].Time)
+}
+
+func TestOrderExpiry(t *testing.T) {
+	order := MyOrders{End: 1483351200000}
+	assert.Equal(t, time.Date(2017, 1, 2, 10, 0, 0, 0, time.UTC), order.ExpiresAt())
+	assert.Equal(t, time.Duration(0), order.TimeRemaining())
+
+	order.End = uint64(time.Now().Add(time.Hour).UnixMilli())
+	assert.InDelta(t, float64(time.Hour), float64(order.TimeRemaining()), float64(time.Minute))
+
+	order.End = 0
+	assert.True(t, order.ExpiresAt().IsZero())
+	assert.Equal(t, time.Duration(0), order.TimeRemaining())
+}
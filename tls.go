@@ -0,0 +1,105 @@
+package nicehash
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// SetInsecureSkipVerify disables the verification of the server certificate.
+// Verification is enabled by default; only turn it off for testing.
+func (client *NicehashClient) SetInsecureSkipVerify(skip bool) error {
+	client.httpClient.insecureSkipVerify = skip
+	return client.httpClient.configureTLS()
+}
+
+// SetRootCAs sets the root certificate authorities used to verify the server.
+// A nil pool means the system roots.
+func (client *NicehashClient) SetRootCAs(pool *x509.CertPool) error {
+	client.httpClient.rootCAs = pool
+	return client.httpClient.configureTLS()
+}
+
+// SetCertificatePins pins the server certificate chain to the given public
+// keys. Each pin is the base64 encoded SHA-256 digest of a DER encoded
+// SubjectPublicKeyInfo; the connection is refused unless one certificate
+// of the chain matches. Calling it without pins removes the pinning.
+func (client *NicehashClient) SetCertificatePins(pins ...string) error {
+	decoded := make([][]byte, 0, len(pins))
+	for _, pin := range pins {
+		digest, err := base64.StdEncoding.DecodeString(pin)
+		if err != nil {
+			return fmt.Errorf("nicehash: invalid certificate pin %q: %v", pin, err)
+		}
+		if len(digest) != sha256.Size {
+			return fmt.Errorf("nicehash: invalid certificate pin %q: not a sha256 digest", pin)
+		}
+		decoded = append(decoded, digest)
+	}
+	client.httpClient.pins = decoded
+	return client.httpClient.configureTLS()
+}
+
+// CertificatePin returns the pin of cert in the format accepted by
+// SetCertificatePins.
+func CertificatePin(cert *x509.Certificate) string {
+	digest := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+	return base64.StdEncoding.EncodeToString(digest[:])
+}
+
+// configureTLS builds the private http client which carries the tls
+// settings. The transport of the caller's client (or http.DefaultTransport)
+// is cloned, so the settings never leak to other users of it.
+func (d *nicehashHttpClient) configureTLS() error {
+	if !d.insecureSkipVerify && d.rootCAs == nil && len(d.pins) == 0 {
+		d.tlsClient = nil
+		return nil
+	}
+	base := d.client
+	if base == nil {
+		base = http.DefaultClient
+	}
+	roundTripper := base.Transport
+	if roundTripper == nil {
+		roundTripper = http.DefaultTransport
+	}
+	transport, ok := roundTripper.(*http.Transport)
+	if !ok {
+		return fmt.Errorf("nicehash: cannot apply tls settings to transport of type %T", roundTripper)
+	}
+	transport = transport.Clone()
+	if transport.TLSClientConfig == nil {
+		transport.TLSClientConfig = &tls.Config{}
+	}
+	transport.TLSClientConfig.InsecureSkipVerify = d.insecureSkipVerify
+	if d.rootCAs != nil {
+		transport.TLSClientConfig.RootCAs = d.rootCAs
+	}
+	if len(d.pins) > 0 {
+		pins := d.pins
+		transport.TLSClientConfig.VerifyConnection = func(state tls.ConnectionState) error {
+			return verifyPins(state.PeerCertificates, pins)
+		}
+	}
+	owned := *base
+	owned.Transport = transport
+	d.tlsClient = &owned
+	return nil
+}
+
+func verifyPins(certs []*x509.Certificate, pins [][]byte) error {
+	for _, cert := range certs {
+		digest := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+		for _, pin := range pins {
+			if bytes.Equal(digest[:], pin) {
+				return nil
+			}
+		}
+	}
+	return errors.New("nicehash: server certificate does not match any pin")
+}
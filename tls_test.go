@@ -0,0 +1,91 @@
+package nicehash
+
+import (
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"github.com/stretchr/testify/assert"
+)
+
+func testTLSServer() *httptest.Server {
+	return httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"result":{"api_version":"1.0.1"},"method":null}`)
+	}))
+}
+
+func skipsVerify(transport *http.Transport) bool {
+	return transport.TLSClientConfig != nil && transport.TLSClientConfig.InsecureSkipVerify
+}
+
+func TestTLSVerifyByDefault(t *testing.T) {
+	server := testTLSServer()
+	defer server.Close()
+
+	nicehashClient := NewNicehashClient(&http.Client{}, server.URL, "FAKEID", "FAKEKEY", "")
+	_, err := nicehashClient.GetVersion()
+
+	assert.NotNil(t, err)
+	assert.False(t, skipsVerify(http.DefaultTransport.(*http.Transport)))
+}
+
+func TestTLSInsecureSkipVerify(t *testing.T) {
+	server := testTLSServer()
+	defer server.Close()
+
+	transport := &http.Transport{}
+	nicehashClient := NewNicehashClient(&http.Client{Transport: transport}, server.URL, "FAKEID", "FAKEKEY", "")
+	assert.Nil(t, nicehashClient.SetInsecureSkipVerify(true))
+	version, err := nicehashClient.GetVersion()
+
+	assert.Nil(t, err)
+	assert.Equal(t, "1.0.1", version)
+	assert.False(t, skipsVerify(transport))
+	assert.False(t, skipsVerify(http.DefaultTransport.(*http.Transport)))
+}
+
+func TestTLSRootCAs(t *testing.T) {
+	server := testTLSServer()
+	defer server.Close()
+
+	pool := x509.NewCertPool()
+	pool.AddCert(server.Certificate())
+
+	nicehashClient := NewNicehashClient(nil, server.URL, "FAKEID", "FAKEKEY", "")
+	assert.Nil(t, nicehashClient.SetRootCAs(pool))
+	version, err := nicehashClient.GetVersion()
+
+	assert.Nil(t, err)
+	assert.Equal(t, "1.0.1", version)
+}
+
+func TestTLSCertificatePins(t *testing.T) {
+	server := testTLSServer()
+	defer server.Close()
+
+	pool := x509.NewCertPool()
+	pool.AddCert(server.Certificate())
+
+	nicehashClient := NewNicehashClient(nil, server.URL, "FAKEID", "FAKEKEY", "")
+	assert.Nil(t, nicehashClient.SetRootCAs(pool))
+	assert.Nil(t, nicehashClient.SetCertificatePins("47DEQpj8HBSa+/TImW+5JCeuQeRkm5NMpJWZG3hSuFU="))
+	_, err := nicehashClient.GetVersion()
+	assert.NotNil(t, err)
+
+	assert.Nil(t, nicehashClient.SetCertificatePins(CertificatePin(server.Certificate())))
+	version, err := nicehashClient.GetVersion()
+	assert.Nil(t, err)
+	assert.Equal(t, "1.0.1", version)
+
+	assert.NotNil(t, nicehashClient.SetCertificatePins("not a pin"))
+}
+
+func TestTLSUnsupportedTransport(t *testing.T) {
+	httpClient, _, server := testServer()
+	defer server.Close()
+
+	nicehashClient := NewNicehashClient(httpClient, "", "FAKEID", "FAKEKEY", "")
+	assert.NotNil(t, nicehashClient.SetInsecureSkipVerify(true))
+}
@@ -3,6 +3,7 @@ package nicehash
 import (
 	"net/url"
 	"fmt"
+	"strconv"
 )
 
 type AlgoType int
@@ -37,76 +38,29 @@ const (
 	AlgoTypeX11Gost
 	AlgoTypeSia
 	AlgoTypeBlake2s
+	// AlgoTypeMAX is the number of the algorithms known by this package,
+	// it is also the number of the next algorithm of the api (Skunk).
+	// It is sent as algo=29 like any other algorithm, pass AlgoTypeNone to
+	// leave the algorithm out of a query.
 	AlgoTypeMAX
 )
 
+// AlgoTypeNone means no algorithm, the algo parameter is left out of the
+// queries. It replaces AlgoTypeMAX, which meant no algorithm before the
+// algorithms after AlgoTypeBlake2s could be registered, see RegisterAlgo.
+const AlgoTypeNone AlgoType = -1
+
+// ToString returns the name of the algorithm, "NA" when unknown.
 func (t AlgoType) ToString() string {
-	switch t {
-	case AlgoTypeScrypt:
-		return "Scrypt";
-	case AlgoTypeSHA256:
-		return "SHA256";
-	case AlgoTypeScryptNf:
-		return "ScryptNf";
-	case AlgoTypeX11:
-		return "X11";
-	case AlgoTypeX13:
-		return "X13";
-	case AlgoTypeKeccak:
-		return "Keccak";
-	case AlgoTypeX15:
-		return "X15";
-	case AlgoTypeNist5:
-		return "Nist5";
-	case AlgoTypeNeoScrypt:
-		return "NeoScrypt";
-	case AlgoTypeLyra2RE:
-		return "Lyra2RE";
-	case AlgoTypeWhirlpoolX:
-		return "WhirlpoolX";
-	case AlgoTypeQubit:
-		return "Qubit";
-	case AlgoTypeQuark:
-		return "Quark";
-	case AlgoTypeAxiom:
-		return "Axiom";
-	case AlgoTypeLyra2REv2:
-		return "Lyra2REv2";
-	case AlgoTypeScryptJaneNf16:
-		return "ScryptJaneNf16";
-	case AlgoTypeBlake256r8:
-		return "Blake256r8";
-	case AlgoTypeBlake256r14:
-		return "Blake256r14";
-	case AlgoTypeBlake256r8vnl:
-		return "Blake256r8vnl";
-	case AlgoTypeHodl:
-		return "Hodl";
-	case AlgoTypeDaggerHashimoto:
-		return "DaggerHashimoto";
-	case AlgoTypeDecred:
-		return "Decred";
-	case AlgoTypeCryptoNight:
-		return "CryptoNight";
-	case AlgoTypeLbry:
-		return "Lbry";
-	case AlgoTypeEquihash:
-		return "Equihash";
-	case AlgoTypePascal:
-		return "Pascal";
-	case AlgoTypeX11Gost:
-		return "X11Gost";
-	case AlgoTypeSia:
-		return "Sia";
-	case AlgoTypeBlake2s:
-		return "Blake2s";
+	if info, ok := LookupAlgo(t); ok {
+		return info.Name;
 	}
 	return "NA"
 }
 
 func (t AlgoType) EncodeValues(key string, v *url.Values) error {
-	if (t != AlgoTypeMAX) {
-		v.Add(key, fmt.Sprint(t))
+	if t != AlgoTypeNone {
+		v.Add(key, strconv.Itoa(int(t)))
 	}
 	return nil
 }
@@ -1,17 +1,29 @@
 package nicehash
 
+import "context"
+
 type Version struct {
-	ApiVersion  string `json:"api_version"`
+	ApiVersion string `json:"api_version"`
 }
 
 func (client *NicehashClient) GetVersion() (string, error) {
+	return client.GetVersionContext(context.Background())
+}
+
+func (client *NicehashClient) GetVersionContext(ctx context.Context) (string, error) {
 	version := &struct {
-		Result Version `json:"result"`
+		Result struct {
+			Version
+			Error string `json:"error"`
+		} `json:"result"`
 	}{}
-	_, err := client.sling.New().Get("").ReceiveSuccess(&version)
+	resp, err := client.receive(ctx, client.sling.New().Get(""), &version)
 	if err != nil {
 		return version.Result.ApiVersion, err
 	}
+	if err = client.checkResult("", resp, version.Result.Error); err != nil {
+		return version.Result.ApiVersion, err
+	}
 
-	return version.Result.ApiVersion, err
+	return version.Result.ApiVersion, nil
 }